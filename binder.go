@@ -0,0 +1,80 @@
+package sqlt
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Binder binds parameters in custom template func.
+type Binder interface {
+	// Bind binds value with given name, and returns placeholder.
+	// Argument name is generated from given name, so it does not collide with parameters and other binder funcs.
+	// Same value that is bound with same name is merged on ordinal or named placeholder.
+	Bind(name string, value interface{}) string
+	// Placeholder returns placeholder of value that is bound last with given name.
+	// Value is bound again on positional placeholder, because each placeholder requires own argument.
+	// It returns error when no value is bound with given name.
+	Placeholder(name string) (string, error)
+	// Lookup returns value of parameter. Name can be dotted path (ex: `user.Name`).
+	Lookup(name string) (interface{}, error)
+	// Dialect returns dialect of executing template.
+	Dialect() Dialect
+	// Named returns true if template is executed with named placeholder.
+	Named() bool
+}
+
+// BinderFunc is custom template func that can bind parameters via Binder.
+// Returned error is handled like errors of embedded funcs, so it is annotated in annotative mode.
+type BinderFunc func(b Binder, args ...interface{}) (string, error)
+
+type binder struct {
+	c *context
+}
+
+func (b binder) Bind(name string, value interface{}) string {
+	c := b.c
+	for _, l := range c.binds {
+		if l.name == name && reflect.DeepEqual(l.param.value, value) {
+			return c.Bind(l.param)
+		}
+	}
+	p := newArg(fmt.Sprintf("%s%sbind%d", name, Connector, len(c.binds)+1), "", value)
+	c.binds = append(c.binds, &local{name: name, param: p})
+	return c.Bind(p)
+}
+
+func (b binder) Placeholder(name string) (string, error) {
+	c := b.c
+	for i := len(c.binds) - 1; i >= 0; i-- {
+		if c.binds[i].name == name {
+			return c.Bind(c.binds[i].param), nil
+		}
+	}
+	return "", fmt.Errorf("%q is not bound", name)
+}
+
+func (b binder) Lookup(name string) (interface{}, error) {
+	p, err := b.c.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.value, nil
+}
+
+func (b binder) Dialect() Dialect {
+	return b.c.dialect
+}
+
+func (b binder) Named() bool {
+	return b.c.named
+}
+
+func (c *context) binderFunc(fn BinderFunc) func(...interface{}) string {
+	return func(args ...interface{}) string {
+		s, err := fn(binder{c: c}, args...)
+		if err != nil {
+			return c.errorOutput(err)
+		}
+		return s
+	}
+}
@@ -0,0 +1,299 @@
+package sqlt
+
+import (
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Optional capabilities of dialect.
+// Dialect may implement these interfaces in addition to Dialect,
+// and sqlt checks those with type assertion. When dialect does not implement, default behavior is used.
+
+// IdentifierQuoter quotes identifier (ex: table name, column name).
+type IdentifierQuoter interface {
+	// QuoteIdentifier returns quoted identifier.
+	QuoteIdentifier(name string) string
+}
+
+// Concatenator concatenates string expressions.
+type Concatenator interface {
+	// Concat returns expression that concatenates given expressions.
+	Concat(exprs ...string) string
+}
+
+// Paginator renders pagination clause.
+type Paginator interface {
+	// Paginate returns pagination clause with given placeholders of limit and offset.
+	Paginate(limit, offset string) string
+	// RequiresOrderBy returns true if pagination clause requires `ORDER BY`.
+	RequiresOrderBy() bool
+}
+
+// RowNumPaginator paginates by wrapping whole query with row number (ex: `ROWNUM` on Oracle 11g or earlier).
+// This is used for `paginate` and `limit` with RowNumPagination option.
+type RowNumPaginator interface {
+	// RowNumPaginate returns query that wraps given query with placeholders of maximum row number and offset.
+	RowNumPaginate(query, max, offset string) string
+}
+
+// BoolRenderer renders boolean literal.
+type BoolRenderer interface {
+	// BoolLiteral returns literal of given boolean value.
+	BoolLiteral(b bool) string
+}
+
+// LiteralRenderer renders literals for `lit` func and Interpolate.
+type LiteralRenderer interface {
+	// StringLiteral returns literal of given string.
+	StringLiteral(s string) string
+	// TimeLiteral returns literal of given time.
+	TimeLiteral(t time.Time) string
+	// BytesLiteral returns literal of given binary.
+	BytesLiteral(b []byte) string
+}
+
+// ParamLimiter limits count of bind parameters in a statement.
+type ParamLimiter interface {
+	// MaxBindParams returns maximum count of bind parameters. 0 means unlimited.
+	MaxBindParams() int
+}
+
+// InListLimiter limits count of values in `IN` list.
+type InListLimiter interface {
+	// MaxInListLength returns maximum count of values in `IN` list. 0 means unlimited.
+	MaxInListLength() int
+}
+
+// CurrentTimestamper renders expression of current timestamp.
+type CurrentTimestamper interface {
+	// CurrentTimestamp returns expression of current timestamp.
+	CurrentTimestamp() string
+}
+
+// ListBinder binds list as one parameter, and expands it in SQL.
+// This is used for `in` func with ListBinding option when count of values exceeds limit of dialect.
+type ListBinder interface {
+	// ListValue returns value for binding given list.
+	ListValue(values []interface{}) (interface{}, error)
+	// ListExpression returns subquery that expands list bound to given placeholder.
+	// Values are same as ListValue, and those are given for typing elements.
+	ListExpression(placeholder string, values []interface{}) string
+}
+
+// JSONCaster casts JSON string that is bound to placeholder.
+type JSONCaster interface {
+	// JSONExpression returns expression that casts JSON string bound to given placeholder.
+	JSONExpression(placeholder string) string
+}
+
+// Caster renders type name for casting bound value.
+// Type is one of `int`, `float`, `bool`, `time`, `date` and `string`.
+type Caster interface {
+	// CastType returns SQL type name for given type. Empty string means no cast.
+	CastType(typ string) string
+}
+
+// NullSafeComparer renders comparison that treats NULL as comparable value (ex: `IS NOT DISTINCT FROM`).
+// This is used for `equal` and `notEqual` with NullSafeEquality option.
+type NullSafeComparer interface {
+	// NullSafeEqual returns expression that is true if left equals right, or both are NULL.
+	NullSafeEqual(left, right string) string
+	// NullSafeNotEqual returns negation of NullSafeEqual.
+	NullSafeNotEqual(left, right string) string
+}
+
+// RowValueComparer tells whether row value comparison (ex: `(a, b) > (1, 2)`) is supported.
+// This is used for `seek`, and condition is expanded to `OR` form when not supported.
+type RowValueComparer interface {
+	// RowValueSupported returns true if row value comparison is supported.
+	RowValueSupported() bool
+}
+
+// Lexer describes lexical syntax of dialect for scanning rendered SQL.
+// This is used for Verification option and Interpolate.
+type Lexer interface {
+	// IdentifierQuotes returns pairs of start and end of quoted identifier (ex: `[` and `]`).
+	IdentifierQuotes() [][2]string
+	// BackslashEscape returns true if backslash escapes quote in string literal.
+	BackslashEscape() bool
+	// HashComment returns true if `#` starts line comment.
+	HashComment() bool
+	// DollarQuote returns true if `$$ ... $$` or `$tag$ ... $tag$` is string literal.
+	DollarQuote() bool
+}
+
+// defaultDialect implements optional capabilities with standard SQL.
+type defaultDialect struct{}
+
+func (d defaultDialect) QuoteIdentifier(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+func (d defaultDialect) Concat(exprs ...string) string {
+	return strings.Join(exprs, " || ")
+}
+
+func (d defaultDialect) Paginate(limit, offset string) string {
+	return "LIMIT " + limit + " OFFSET " + offset
+}
+
+func (d defaultDialect) RequiresOrderBy() bool {
+	return false
+}
+
+func (d defaultDialect) BoolLiteral(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+func (d defaultDialect) StringLiteral(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+// TimeLiteral converts time to UTC, because `TIMESTAMP` literal does not have time zone.
+func (d defaultDialect) TimeLiteral(t time.Time) string {
+	return "TIMESTAMP '" + t.UTC().Format("2006-01-02 15:04:05.999999") + "'"
+}
+
+func (d defaultDialect) BytesLiteral(b []byte) string {
+	return "X'" + hex.EncodeToString(b) + "'"
+}
+
+func (d defaultDialect) MaxBindParams() int {
+	return 0
+}
+
+func (d defaultDialect) MaxInListLength() int {
+	return 0
+}
+
+func (d defaultDialect) CurrentTimestamp() string {
+	return "CURRENT_TIMESTAMP"
+}
+
+func (d defaultDialect) JSONExpression(placeholder string) string {
+	return placeholder
+}
+
+func (d defaultDialect) CastType(typ string) string {
+	switch typ {
+	case "int":
+		return "BIGINT"
+	case "float":
+		return "DOUBLE PRECISION"
+	case "bool":
+		return "BOOLEAN"
+	case "time":
+		return "TIMESTAMP"
+	case "date":
+		return "DATE"
+	}
+	return ""
+}
+
+func (d defaultDialect) RowValueSupported() bool {
+	return false
+}
+
+func (d defaultDialect) IdentifierQuotes() [][2]string {
+	return [][2]string{{`"`, `"`}}
+}
+
+func (d defaultDialect) BackslashEscape() bool {
+	return false
+}
+
+func (d defaultDialect) HashComment() bool {
+	return false
+}
+
+func (d defaultDialect) DollarQuote() bool {
+	return false
+}
+
+func identifierQuoterOf(d Dialect) IdentifierQuoter {
+	if q, ok := d.(IdentifierQuoter); ok {
+		return q
+	}
+	return defaultDialect{}
+}
+
+func concatenatorOf(d Dialect) Concatenator {
+	if c, ok := d.(Concatenator); ok {
+		return c
+	}
+	return defaultDialect{}
+}
+
+func currentTimestamperOf(d Dialect) CurrentTimestamper {
+	if t, ok := d.(CurrentTimestamper); ok {
+		return t
+	}
+	return defaultDialect{}
+}
+
+func paginatorOf(d Dialect) Paginator {
+	if p, ok := d.(Paginator); ok {
+		return p
+	}
+	return defaultDialect{}
+}
+
+func literalRendererOf(d Dialect) LiteralRenderer {
+	if r, ok := d.(LiteralRenderer); ok {
+		return r
+	}
+	return defaultDialect{}
+}
+
+func boolRendererOf(d Dialect) BoolRenderer {
+	if r, ok := d.(BoolRenderer); ok {
+		return r
+	}
+	return defaultDialect{}
+}
+
+func paramLimiterOf(d Dialect) ParamLimiter {
+	if l, ok := d.(ParamLimiter); ok {
+		return l
+	}
+	return defaultDialect{}
+}
+
+func inListLimiterOf(d Dialect) InListLimiter {
+	if l, ok := d.(InListLimiter); ok {
+		return l
+	}
+	return defaultDialect{}
+}
+
+func jsonCasterOf(d Dialect) JSONCaster {
+	if c, ok := d.(JSONCaster); ok {
+		return c
+	}
+	return defaultDialect{}
+}
+
+func casterOf(d Dialect) Caster {
+	if c, ok := d.(Caster); ok {
+		return c
+	}
+	return defaultDialect{}
+}
+
+func rowValueComparerOf(d Dialect) RowValueComparer {
+	if r, ok := d.(RowValueComparer); ok {
+		return r
+	}
+	return defaultDialect{}
+}
+
+func lexerOf(d Dialect) Lexer {
+	if l, ok := d.(Lexer); ok {
+		return l
+	}
+	return defaultDialect{}
+}
@@ -0,0 +1,164 @@
+package sqlt_test
+
+import (
+	"testing"
+
+	"github.com/pinzolo/sqlt"
+)
+
+func TestCapabilities(t *testing.T) {
+	data := []struct {
+		dialect   sqlt.Dialect
+		quoted    string
+		concat    string
+		paginate  string
+		boolTrue  string
+		maxParams int
+		maxInList int
+		now       string
+		tag       string
+	}{
+		{sqlt.Postgres, `"a""b"`, `a || b`, `LIMIT l OFFSET o`, `TRUE`, 65535, 0, `CURRENT_TIMESTAMP`, "postgres"},
+		{sqlt.MySQL, "`a\"b`", `CONCAT(a, b)`, `LIMIT l OFFSET o`, `TRUE`, 65535, 0, `CURRENT_TIMESTAMP`, "mysql"},
+		{sqlt.Oracle, `"a""b"`, `a || b`, `OFFSET o ROWS FETCH NEXT l ROWS ONLY`, `1`, 65535, 1000, `SYSTIMESTAMP`, "oracle"},
+		{sqlt.SQLServer, `[a"b]`, `a + b`, `OFFSET o ROWS FETCH NEXT l ROWS ONLY`, `1`, 2100, 0, `SYSDATETIME()`, "sqlserver"},
+		{sqlt.SQLite, `"a""b"`, `a || b`, `LIMIT l OFFSET o`, `1`, 32766, 0, `CURRENT_TIMESTAMP`, "sqlite"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			if s := d.dialect.(sqlt.IdentifierQuoter).QuoteIdentifier(`a"b`); s != d.quoted {
+				t.Errorf("quoted identifier should be %s, but got %s", d.quoted, s)
+			}
+			if s := d.dialect.(sqlt.Concatenator).Concat("a", "b"); s != d.concat {
+				t.Errorf("concatenation should be %s, but got %s", d.concat, s)
+			}
+			if s := d.dialect.(sqlt.Paginator).Paginate("l", "o"); s != d.paginate {
+				t.Errorf("pagination should be %s, but got %s", d.paginate, s)
+			}
+			if s := d.dialect.(sqlt.BoolRenderer).BoolLiteral(true); s != d.boolTrue {
+				t.Errorf("true literal should be %s, but got %s", d.boolTrue, s)
+			}
+			if n := d.dialect.(sqlt.ParamLimiter).MaxBindParams(); n != d.maxParams {
+				t.Errorf("max bind params should be %d, but got %d", d.maxParams, n)
+			}
+			if n := d.dialect.(sqlt.InListLimiter).MaxInListLength(); n != d.maxInList {
+				t.Errorf("max IN list length should be %d, but got %d", d.maxInList, n)
+			}
+			if s := d.dialect.(sqlt.CurrentTimestamper).CurrentTimestamp(); s != d.now {
+				t.Errorf("current timestamp should be %s, but got %s", d.now, s)
+			}
+		})
+	}
+}
+
+// db2 is custom dialect that implements Paginator with positional placeholder.
+type db2 struct{}
+
+func (d db2) IsOrdinalPlaceholderSupported() bool { return false }
+func (d db2) OrdinalPlaceholderPrefix() string    { return "" }
+func (d db2) Placeholder() string                 { return "?" }
+func (d db2) NamedPlaceholderPrefix() string      { return ":" }
+func (d db2) WildcardRunes() []rune               { return []rune{'%', '_'} }
+
+func (d db2) Paginate(limit, offset string) string {
+	return "OFFSET " + offset + " ROWS FETCH FIRST " + limit + " ROWS ONLY"
+}
+
+func (d db2) RequiresOrderBy() bool {
+	return false
+}
+
+func TestCustomPaginator(t *testing.T) {
+	s := `SELECT * FROM users /*% paginate "page" "size" %*/`
+	query, args, err := sqlt.New(db2{}).Exec(s, map[string]interface{}{
+		"page": 3,
+		"size": 10,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	eSQL := `SELECT * FROM users OFFSET ? ROWS FETCH FIRST ? ROWS ONLY`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 2 {
+		t.Fatalf("exec failed: values should have 2 length, but got %v", args)
+	}
+	if isInvalidInt(args[0], 20) {
+		t.Errorf("exec failed: offset should be bound first, but got %v", args)
+	}
+	if isInvalidInt(args[1], 10) {
+		t.Errorf("exec failed: limit should be bound second, but got %v", args)
+	}
+}
+
+func TestCapabilityFuncs(t *testing.T) {
+	s := `SELECT /*% ident "col" %*/ , /*% concat "first_name" "' '" "last_name" %*/ AS name, /*% currentTimestamp %*/ AS now FROM users`
+	data := []struct {
+		dialect sqlt.Dialect
+		sql     string
+		tag     string
+	}{
+		{sqlt.Postgres, `SELECT "u"."user""name" , first_name || ' ' || last_name AS name, CURRENT_TIMESTAMP AS now FROM users`, "postgres"},
+		{sqlt.MySQL, "SELECT `u`.`user\"name` , CONCAT(first_name, ' ', last_name) AS name, CURRENT_TIMESTAMP AS now FROM users", "mysql"},
+		{sqlt.SQLServer, `SELECT [u].[user"name] , first_name + ' ' + last_name AS name, SYSDATETIME() AS now FROM users`, "sqlserver"},
+		{sqlt.Oracle, `SELECT "u"."user""name" , first_name || ' ' || last_name AS name, SYSTIMESTAMP AS now FROM users`, "oracle"},
+		{db2{}, `SELECT "u"."user""name" , first_name || ' ' || last_name AS name, CURRENT_TIMESTAMP AS now FROM users`, "default"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			query, _, err := sqlt.New(d.dialect).Exec(s, map[string]interface{}{"col": `u.user"name`})
+			if err != nil {
+				t.Error(err)
+			}
+			if d.sql != query {
+				t.Errorf("exec failed: expected %s, but got %s", d.sql, query)
+			}
+		})
+	}
+}
+
+func TestIdentError(t *testing.T) {
+	for _, v := range []interface{}{1, ""} {
+		if _, _, err := sqlt.New(sqlt.Postgres).Exec(`SELECT /*% ident "col" %*/ FROM users`, map[string]interface{}{"col": v}); err == nil {
+			t.Errorf("should raise error for %v", v)
+		}
+	}
+}
+
+// rowValueDB2 is custom dialect that supports row value comparison.
+type rowValueDB2 struct {
+	db2
+}
+
+func (d rowValueDB2) RowValueSupported() bool {
+	return true
+}
+
+func TestCustomRowValueComparer(t *testing.T) {
+	cursor, err := sqlt.EncodeCursor(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := `SELECT * FROM users WHERE /*% seek "cursor" "a, b" %*/`
+	data := []struct {
+		dialect sqlt.Dialect
+		sql     string
+		tag     string
+	}{
+		{rowValueDB2{}, `SELECT * FROM users WHERE (a, b) > (?, ?)`, "supported"},
+		{db2{}, `SELECT * FROM users WHERE (a > ? OR (a = ? AND b > ?))`, "not supported"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			query, _, err := sqlt.New(d.dialect).Exec(s, map[string]interface{}{"cursor": cursor})
+			if err != nil {
+				t.Error(err)
+			}
+			if d.sql != query {
+				t.Errorf("exec failed: expected %s, but got %s", d.sql, query)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package sqlt
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTimeLayout is layout for converting string to time by `cast` with `time` type.
+	DefaultTimeLayout = time.RFC3339
+	// DefaultDateLayout is layout for converting string to time by `cast` with `date` type.
+	DefaultDateLayout = "2006-01-02"
+)
+
+// ErrConversion is error that value cannot be converted by `cast`.
+type ErrConversion struct {
+	// Param is name of parameter.
+	Param string
+	// Type is type name of conversion (ex: `int`).
+	Type string
+	// Value is value of parameter.
+	Value interface{}
+	// Err is cause of failure.
+	Err error
+}
+
+func (e *ErrConversion) Error() string {
+	return fmt.Sprintf("%q cannot be converted to %s(%s)", e.Param, e.Type, e.Err.Error())
+}
+
+func (c *context) cast(name string, typ string, layout ...string) string {
+	p, err := c.Get(name)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+
+	v, err := convert(p.value, typ, layout)
+	if err != nil {
+		return c.errorOutput(&ErrConversion{Param: name, Type: typ, Value: p.value, Err: err})
+	}
+	ph := c.Bind(newArg(p.name+Connector+typ, p.path, v))
+	if c.config.sqlCast {
+		if t := casterOf(c.dialect).CastType(typ); t != "" {
+			ph = "CAST(" + ph + " AS " + t + ")"
+		}
+	}
+	return ph + c.annotation(name)
+}
+
+func convert(v interface{}, typ string, layout []string) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil, nil
+	}
+
+	switch typ {
+	case "int":
+		return toInt64(rv)
+	case "float":
+		return toFloat64(rv)
+	case "bool":
+		return toBool(rv)
+	case "time":
+		return toTime(rv, layoutOf(layout, DefaultTimeLayout))
+	case "date":
+		return toTime(rv, layoutOf(layout, DefaultDateLayout))
+	case "string":
+		return fmt.Sprintf("%v", rv.Interface()), nil
+	}
+	return nil, errors.New("unknown type")
+}
+
+func layoutOf(layout []string, def string) string {
+	if len(layout) > 0 && layout[0] != "" {
+		return layout[0]
+	}
+	return def
+}
+
+func toInt64(rv reflect.Value) (interface{}, error) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return nil, errors.New("out of range")
+		}
+		return int64(rv.Uint()), nil
+	case reflect.String:
+		n, err := strconv.ParseInt(strings.TrimSpace(rv.String()), 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid syntax")
+		}
+		return n, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", rv.Type())
+}
+
+func toFloat64(rv reflect.Value) (interface{}, error) {
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	case reflect.String:
+		f, err := strconv.ParseFloat(strings.TrimSpace(rv.String()), 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, errors.New("invalid syntax")
+		}
+		return f, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", rv.Type())
+}
+
+func toBool(rv reflect.Value) (interface{}, error) {
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.String:
+		b, err := strconv.ParseBool(strings.TrimSpace(rv.String()))
+		if err != nil {
+			return nil, errors.New("invalid syntax")
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", rv.Type())
+}
+
+func toTime(rv reflect.Value, layout string) (interface{}, error) {
+	if t, ok := rv.Interface().(time.Time); ok {
+		return t, nil
+	}
+	if rv.Kind() == reflect.String {
+		t, err := time.Parse(layout, strings.TrimSpace(rv.String()))
+		if err != nil {
+			return nil, fmt.Errorf("not match layout %s", layout)
+		}
+		return t, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", rv.Type())
+}
@@ -0,0 +1,126 @@
+package sqlt
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// equal and notEqual are not named `eq` and `ne`, because those are builtin funcs of text/template.
+func (c *context) equal(col string, name string) string {
+	return c.compare(col, name, false)
+}
+
+func (c *context) notEqual(col string, name string) string {
+	return c.compare(col, name, true)
+}
+
+func (c *context) compare(col string, name string, not bool) string {
+	p, err := c.GetNullable(name)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+
+	null, err := isNull(p.value)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	if null {
+		if not {
+			return col + " IS NOT NULL" + c.annotation(name)
+		}
+		return col + " IS NULL" + c.annotation(name)
+	}
+
+	ph := c.Bind(newArg(p.name, p.path, p.value))
+	if nc, ok := c.dialect.(NullSafeComparer); ok && c.config.nullSafe {
+		if not {
+			return nc.NullSafeNotEqual(col, ph) + c.annotation(name)
+		}
+		return nc.NullSafeEqual(col, ph) + c.annotation(name)
+	}
+	if not {
+		return col + " <> " + ph + c.annotation(name)
+	}
+	return col + " = " + ph + c.annotation(name)
+}
+
+// isNull returns true if given value is bound as NULL.
+func isNull(v interface{}) (bool, error) {
+	if v == nil {
+		return true, nil
+	}
+	rv := reflect.ValueOf(v)
+	if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && rv.IsNil() {
+		return true, nil
+	}
+	if vr, ok := v.(driver.Valuer); ok {
+		dv, err := vr.Value()
+		if err != nil {
+			return false, err
+		}
+		return isNull(dv)
+	}
+	if rv.Kind() == reflect.Ptr {
+		return isNull(rv.Elem().Interface())
+	}
+	return false, nil
+}
+
+// between is not named `range`, because it is keyword of text/template.
+// Bounds are `[]` (default, closed), `[)` (half-open), `(]` and `()`.
+func (c *context) between(col string, from string, to string, bounds ...string) string {
+	b := "[]"
+	if len(bounds) > 0 {
+		b = bounds[0]
+	}
+	if len(b) != 2 || !strings.ContainsRune("[(", rune(b[0])) || !strings.ContainsRune("])", rune(b[1])) {
+		return c.errorOutput(fmt.Errorf("%q is invalid bounds", b))
+	}
+
+	fp, err := c.bound(from)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	tp, err := c.bound(to)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+
+	lop, uop := " >= ", " <= "
+	if b[0] == '(' {
+		lop = " > "
+	}
+	if b[1] == ')' {
+		uop = " < "
+	}
+	switch {
+	case fp != nil && tp != nil:
+		if b == "[]" {
+			return col + " BETWEEN " + c.Bind(fp) + c.annotation(from) + " AND " + c.Bind(tp) + c.annotation(to)
+		}
+		return col + lop + c.Bind(fp) + c.annotation(from) + " AND " + col + uop + c.Bind(tp) + c.annotation(to)
+	case fp != nil:
+		return col + lop + c.Bind(fp) + c.annotation(from)
+	case tp != nil:
+		return col + uop + c.Bind(tp) + c.annotation(to)
+	}
+	return ""
+}
+
+// bound returns nil if value of parameter is absent (NULL or empty string).
+func (c *context) bound(name string) (*param, error) {
+	p, err := c.GetNullable(name)
+	if err != nil {
+		return nil, err
+	}
+	null, err := isNull(p.value)
+	if err != nil {
+		return nil, err
+	}
+	if null || p.value == "" {
+		return nil, nil
+	}
+	return newArg(p.name, p.path, p.value), nil
+}
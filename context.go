@@ -1,6 +1,7 @@
 package sqlt
 
 import (
+	"bytes"
 	"database/sql"
 	"fmt"
 	"reflect"
@@ -16,15 +17,28 @@ const (
 type param struct {
 	name  string
 	value interface{}
+	// path is source parameter path of value (ex: `ids.2`).
+	path string
 }
 
 func newParam(name string, value interface{}) *param {
+	return newArg(name, name, value)
+}
+
+func newArg(name string, path string, value interface{}) *param {
 	return &param{
 		name:  name,
 		value: value,
+		path:  path,
 	}
 }
 
+// local is template local parameter that is defined by `let` or `alias`, or argument that is bound by Binder.
+type local struct {
+	name  string
+	param *param
+}
+
 type context struct {
 	named   bool
 	dialect Dialect
@@ -33,6 +47,24 @@ type context struct {
 	timer   *timer
 	config  *config
 	err     error
+	// buf is output buffer of executing template.
+	buf *bytes.Buffer
+	// pagination is deferred pagination for wrapping whole query.
+	pagination *pagination
+	// counting is true when executing template for counting rows.
+	counting bool
+	// refs are names of referenced parameters.
+	refs []string
+	// warnings are all errors that are occurred in executing template.
+	warnings []error
+	// lists are names of parameters that are expanded by `in` and its count.
+	lists map[string]int
+	// values are arguments that are bound by `pv` or `bind`.
+	values []*param
+	// locals are parameters that are defined in template, these are prior to root parameters.
+	locals []*local
+	// binds are arguments that are bound by Binder with requested name.
+	binds []*local
 }
 
 func newContext(named bool, dialect Dialect, m map[string]interface{}, conf *config) *context {
@@ -49,13 +81,46 @@ func newContext(named bool, dialect Dialect, m map[string]interface{}, conf *con
 		args:    make([]*param, 0),
 		timer:   newTimer(conf.timeFunc),
 		config:  conf,
+		lists:   make(map[string]int),
 	}
 }
 
 func (c *context) Get(name string) (*param, error) {
+	c.refer(name)
+	return c.lookup(name)
+}
+
+// refer records root path of referenced parameter.
+// Local name is resolved to path of source parameter, and value defined in template (ex: `let`) is not recorded.
+func (c *context) refer(name string) {
+	names := strings.Split(name, ".")
+	for i := len(c.locals) - 1; i >= 0; i-- {
+		if c.locals[i].name == names[0] {
+			if c.locals[i].param.path == "" {
+				return
+			}
+			names[0] = c.locals[i].param.path
+			name = strings.Join(names, ".")
+			break
+		}
+	}
+	for _, ref := range c.refs {
+		if ref == name {
+			return
+		}
+	}
+	c.refs = append(c.refs, name)
+}
+
+func (c *context) lookup(name string) (*param, error) {
 	if strings.Contains(name, ".") {
 		return c.Dig(strings.Split(name, "."))
 	}
+	for i := len(c.locals) - 1; i >= 0; i-- {
+		if c.locals[i].name == name {
+			return c.locals[i].param, nil
+		}
+	}
 	for _, p := range c.params {
 		if p.name == name {
 			return p, nil
@@ -64,8 +129,21 @@ func (c *context) Get(name string) (*param, error) {
 	return nil, fmt.Errorf("%q is unknown param", name)
 }
 
+// GetNullable returns parameter like Get, but nil pointer at last of path is returned as nil value instead of error.
+func (c *context) GetNullable(name string) (*param, error) {
+	c.refer(name)
+	if strings.Contains(name, ".") {
+		return c.dig(strings.Split(name, "."), true)
+	}
+	return c.lookup(name)
+}
+
 func (c *context) Dig(names []string) (*param, error) {
-	p, err := c.Get(names[0])
+	return c.dig(names, false)
+}
+
+func (c *context) dig(names []string, nullable bool) (*param, error) {
+	p, err := c.lookup(names[0])
 	if err != nil {
 		return nil, err
 	}
@@ -75,19 +153,30 @@ func (c *context) Dig(names []string) (*param, error) {
 		return nil, fmt.Errorf("%q is nil value", qname)
 	}
 	v := reflect.ValueOf(p.value)
-	for _, name := range names[1:] {
+	for i, name := range names[1:] {
 		v, err = findValue(v, name, qname)
 		if err != nil {
 			return nil, err
 		}
 		qname = qname + "." + name
 		if v.Kind() == reflect.Ptr {
-			if v.IsNil() {
+			if v.IsNil() && !(nullable && i == len(names)-2) {
 				return nil, fmt.Errorf("%q is nil value", qname)
 			}
 		}
 	}
-	return newParam(strings.Join(names, Connector), v.Interface()), nil
+	// Use name and path of found parameter, because it may be local parameter.
+	rest := names[1:]
+	return newArg(strings.Join(append([]string{p.name}, rest...), Connector), subPath(p.path, rest...), v.Interface()), nil
+}
+
+// subPath returns path of child value.
+// Child of value that is defined in template has no path.
+func subPath(path string, names ...string) string {
+	if path == "" {
+		return ""
+	}
+	return strings.Join(append([]string{path}, names...), ".")
 }
 
 func findValue(val reflect.Value, name string, prefix string) (reflect.Value, error) {
@@ -160,17 +249,55 @@ func findIndexValue(val reflect.Value, i int, prefix string) (reflect.Value, err
 	return val.Index(i), nil
 }
 
-func (c *context) AddArg(name string, value interface{}) {
-	c.args = append(c.args, newParam(name, value))
+func (c *context) define(name string, p *param) {
+	c.locals = append(c.locals, &local{name: name, param: p})
 }
 
-func (c *context) MergeArg(name string, value interface{}) {
-	for _, arg := range c.args {
-		if arg.name == name {
+// freeName returns name that is generated by format with counter, and does not collide with parameters and arguments.
+// Counter is advanced from n while generated name is used.
+func (c *context) freeName(format string, n int) string {
+	for ; ; n++ {
+		name := fmt.Sprintf(format, n)
+		if !c.used(name) {
+			return name
+		}
+	}
+}
+
+func (c *context) used(name string) bool {
+	for _, p := range c.params {
+		if p.name == name {
+			return true
+		}
+	}
+	for _, a := range c.args {
+		if a.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *context) AddArg(arg *param) {
+	c.args = append(c.args, arg)
+}
+
+func (c *context) MergeArg(arg *param) {
+	for _, a := range c.args {
+		if a.name == arg.name {
 			return
 		}
 	}
-	c.AddArg(name, value)
+	c.AddArg(arg)
+}
+
+func (c *context) Bind(arg *param) string {
+	if c.named || c.dialect.IsOrdinalPlaceholderSupported() {
+		c.MergeArg(arg)
+	} else {
+		c.AddArg(arg)
+	}
+	return c.Placeholder(arg.name)
 }
 
 func (c *context) ArgIndex(name string) int {
@@ -200,7 +327,7 @@ func (c *context) NamedArgs() []sql.NamedArg {
 
 func (c *context) Placeholder(name string) string {
 	if c.named {
-		return c.dialect.NamedPlaceholderPrefix() + name
+		return c.namedPlaceholderPrefix() + name
 	}
 	if c.dialect.IsOrdinalPlaceholderSupported() {
 		return c.dialect.OrdinalPlaceholderPrefix() + strconv.Itoa(c.ArgIndex(name))
@@ -208,14 +335,28 @@ func (c *context) Placeholder(name string) string {
 	return c.dialect.Placeholder()
 }
 
+func (c *context) namedPlaceholderPrefix() string {
+	if c.config.profile != nil {
+		return c.config.profile.NamedPlaceholderPrefix()
+	}
+	return c.dialect.NamedPlaceholderPrefix()
+}
+
+// annotation returns comment for debugging.
+// End of comment in given text is broken, because text may contain value from client.
 func (c *context) annotation(s string) string {
 	if c.config.annotative {
-		return "/*# " + s + " */"
+		return "/*# " + strings.Replace(s, "*/", "* /", -1) + " */"
 	}
 	return ""
 }
 
-func (c *context) errorOutput(err error) string {
+func (c *context) setError(err error) {
 	c.err = err
+	c.warnings = append(c.warnings, err)
+}
+
+func (c *context) errorOutput(err error) string {
+	c.setError(err)
 	return c.annotation("error: " + err.Error())
 }
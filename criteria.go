@@ -0,0 +1,134 @@
+package sqlt
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TagName is struct tag name that is read by `criteria`, `columns`, `insertValues` and `insert`.
+const TagName = "sqlt"
+
+// fieldTag is parsed struct tag (ex: `sqlt:"u.name,op=infix"`).
+type fieldTag struct {
+	column  string
+	options map[string]string
+}
+
+func parseTag(tag string) fieldTag {
+	ss := strings.Split(tag, ",")
+	ft := fieldTag{column: strings.TrimSpace(ss[0]), options: make(map[string]string)}
+	for _, s := range ss[1:] {
+		kv := strings.SplitN(strings.TrimSpace(s), "=", 2)
+		if len(kv) == 2 {
+			ft.options[kv[0]] = kv[1]
+		} else {
+			ft.options[kv[0]] = ""
+		}
+	}
+	return ft
+}
+
+func (ft fieldTag) has(opt string) bool {
+	_, ok := ft.options[opt]
+	return ok
+}
+
+// taggedField is exported field that has `sqlt` tag.
+type taggedField struct {
+	name  string
+	tag   fieldTag
+	value reflect.Value
+}
+
+// taggedFields returns fields of struct that have `sqlt` tag.
+func taggedFields(name string, v interface{}) ([]taggedField, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("%q is nil value", name)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%q is not struct", name)
+	}
+
+	rt := rv.Type()
+	fields := make([]taggedField, 0, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		tag, ok := sf.Tag.Lookup(TagName)
+		if !ok || tag == "-" || sf.PkgPath != "" {
+			continue
+		}
+		ft := parseTag(tag)
+		if ft.column == "" {
+			ft.column = sf.Name
+		}
+		fields = append(fields, taggedField{name: sf.Name, tag: ft, value: rv.Field(i)})
+	}
+	return fields, nil
+}
+
+func (c *context) criteria(name string) string {
+	p, err := c.Get(name)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	fields, err := taggedFields(name, p.value)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+
+	conds := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if isZero(f.value) {
+			continue
+		}
+		cond, err := c.condition(f.tag.column, f.tag.options["op"], name+"."+f.name)
+		if err != nil {
+			return c.errorOutput(err)
+		}
+		conds = append(conds, cond)
+	}
+	// Always true condition keeps `WHERE` clause valid when no field is given.
+	if len(conds) == 0 {
+		return "1 = 1" + c.annotation(name)
+	}
+	return strings.Join(conds, " AND ")
+}
+
+func (c *context) condition(col string, op string, name string) (string, error) {
+	switch op {
+	case "", "eq":
+		return col + " = " + c.param(name), nil
+	case "ne":
+		return col + " <> " + c.param(name), nil
+	case "gt":
+		return col + " > " + c.param(name), nil
+	case "gte":
+		return col + " >= " + c.param(name), nil
+	case "lt":
+		return col + " < " + c.param(name), nil
+	case "lte":
+		return col + " <= " + c.param(name), nil
+	case "in":
+		return col + " IN " + c.in(name), nil
+	case "prefix":
+		return col + " LIKE " + c.prefix(name), nil
+	case "infix":
+		return col + " LIKE " + c.infix(name), nil
+	case "suffix":
+		return col + " LIKE " + c.suffix(name), nil
+	}
+	return "", fmt.Errorf("%q has unknown operator %q", name, op)
+}
+
+// isZero returns true if value is zero value of its type or empty slice.
+func isZero(v reflect.Value) bool {
+	if v.Kind() == reflect.Slice {
+		return v.Len() == 0
+	}
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
@@ -1,5 +1,11 @@
 package sqlt
 
+import (
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
 // Dialect resolves dialect of each databse.
 type Dialect interface {
 	// IsOrdinalPlaceholderSupportedreturns true if databse support ordinal placeholder.
@@ -27,6 +33,8 @@ var (
 	Oracle = oracle{}
 	// SQLServer is SQLServer dialect resolver.
 	SQLServer = sqlserver{}
+	// SQLite is SQLite dialect resolver.
+	SQLite = sqlite{}
 )
 
 type postgres struct{}
@@ -51,6 +59,128 @@ func (p postgres) WildcardRunes() []rune {
 	return []rune{'%', '_'}
 }
 
+func (p postgres) QuoteIdentifier(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+func (p postgres) IdentifierQuotes() [][2]string {
+	return [][2]string{{`"`, `"`}}
+}
+
+func (p postgres) BackslashEscape() bool {
+	return false
+}
+
+func (p postgres) HashComment() bool {
+	return false
+}
+
+func (p postgres) DollarQuote() bool {
+	return true
+}
+
+func (p postgres) Concat(exprs ...string) string {
+	return strings.Join(exprs, " || ")
+}
+
+func (p postgres) Paginate(limit, offset string) string {
+	return "LIMIT " + limit + " OFFSET " + offset
+}
+
+func (p postgres) RequiresOrderBy() bool {
+	return false
+}
+
+func (p postgres) BoolLiteral(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// StringLiteral uses escape string for not depending on `standard_conforming_strings`.
+func (p postgres) StringLiteral(s string) string {
+	s = strings.Replace(s, "'", "''", -1)
+	if strings.Contains(s, `\`) {
+		return "E'" + strings.Replace(s, `\`, `\\`, -1) + "'"
+	}
+	return "'" + s + "'"
+}
+
+func (p postgres) TimeLiteral(t time.Time) string {
+	return "TIMESTAMP WITH TIME ZONE '" + t.Format("2006-01-02 15:04:05.999999-07:00") + "'"
+}
+
+// BytesLiteral uses `decode` for not depending on `standard_conforming_strings`.
+func (p postgres) BytesLiteral(b []byte) string {
+	return "decode('" + hex.EncodeToString(b) + "', 'hex')"
+}
+
+func (p postgres) MaxBindParams() int {
+	return 65535
+}
+
+func (p postgres) MaxInListLength() int {
+	return 0
+}
+
+func (p postgres) CurrentTimestamp() string {
+	return "CURRENT_TIMESTAMP"
+}
+
+func (p postgres) JSONExpression(placeholder string) string {
+	return placeholder + "::jsonb"
+}
+
+func (p postgres) CastType(typ string) string {
+	switch typ {
+	case "int":
+		return "BIGINT"
+	case "float":
+		return "DOUBLE PRECISION"
+	case "bool":
+		return "BOOLEAN"
+	case "time":
+		return "TIMESTAMP"
+	case "date":
+		return "DATE"
+	case "string":
+		return "TEXT"
+	}
+	return ""
+}
+
+func (p postgres) NullSafeEqual(left, right string) string {
+	return left + " IS NOT DISTINCT FROM " + right
+}
+
+func (p postgres) NullSafeNotEqual(left, right string) string {
+	return left + " IS DISTINCT FROM " + right
+}
+
+func (p postgres) RowValueSupported() bool {
+	return true
+}
+
+func (p postgres) ListValue(values []interface{}) (interface{}, error) {
+	return jsonList(values)
+}
+
+func (p postgres) ListExpression(placeholder string, values []interface{}) string {
+	typ := "text"
+	switch listElementType(values) {
+	case "int":
+		typ = "bigint"
+	case "float":
+		typ = "double precision"
+	case "bool":
+		typ = "boolean"
+	case "time":
+		typ = "timestamptz"
+	}
+	return "SELECT jsonb_array_elements_text(" + placeholder + "::jsonb)::" + typ
+}
+
 type mysql struct{}
 
 func (m mysql) IsOrdinalPlaceholderSupported() bool {
@@ -73,6 +203,113 @@ func (m mysql) WildcardRunes() []rune {
 	return []rune{'%', '_'}
 }
 
+func (m mysql) QuoteIdentifier(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+func (m mysql) IdentifierQuotes() [][2]string {
+	return [][2]string{{"`", "`"}}
+}
+
+// BackslashEscape returns true, because backslash is escape character by default on MySQL.
+func (m mysql) BackslashEscape() bool {
+	return true
+}
+
+func (m mysql) HashComment() bool {
+	return true
+}
+
+func (m mysql) DollarQuote() bool {
+	return false
+}
+
+// Concat uses `CONCAT` function, because `||` is logical OR by default on MySQL.
+func (m mysql) Concat(exprs ...string) string {
+	return "CONCAT(" + strings.Join(exprs, ", ") + ")"
+}
+
+func (m mysql) Paginate(limit, offset string) string {
+	return "LIMIT " + limit + " OFFSET " + offset
+}
+
+func (m mysql) RequiresOrderBy() bool {
+	return false
+}
+
+func (m mysql) BoolLiteral(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// StringLiteral escapes backslash, because MySQL treats backslash as escape character in string literal by default.
+func (m mysql) StringLiteral(s string) string {
+	return "'" + strings.Replace(strings.Replace(s, "'", "''", -1), `\`, `\\`, -1) + "'"
+}
+
+// TimeLiteral converts time to UTC, because `TIMESTAMP` literal does not have time zone.
+func (m mysql) TimeLiteral(t time.Time) string {
+	return "TIMESTAMP '" + t.UTC().Format("2006-01-02 15:04:05.999999") + "'"
+}
+
+func (m mysql) BytesLiteral(b []byte) string {
+	return "X'" + hex.EncodeToString(b) + "'"
+}
+
+func (m mysql) MaxBindParams() int {
+	return 65535
+}
+
+func (m mysql) MaxInListLength() int {
+	return 0
+}
+
+func (m mysql) CurrentTimestamp() string {
+	return "CURRENT_TIMESTAMP"
+}
+
+func (m mysql) JSONExpression(placeholder string) string {
+	return "CAST(" + placeholder + " AS JSON)"
+}
+
+func (m mysql) CastType(typ string) string {
+	switch typ {
+	case "int":
+		return "SIGNED"
+	case "float":
+		return "DOUBLE"
+	case "time":
+		return "DATETIME"
+	case "date":
+		return "DATE"
+	case "string":
+		return "CHAR"
+	}
+	return ""
+}
+
+func (m mysql) NullSafeEqual(left, right string) string {
+	return left + " <=> " + right
+}
+
+func (m mysql) NullSafeNotEqual(left, right string) string {
+	return "NOT (" + left + " <=> " + right + ")"
+}
+
+func (m mysql) RowValueSupported() bool {
+	return true
+}
+
+func (m mysql) ListValue(values []interface{}) (interface{}, error) {
+	return jsonList(values)
+}
+
+func (m mysql) ListExpression(placeholder string, values []interface{}) string {
+	return "SELECT v FROM JSON_TABLE(" + placeholder + ", '$[*]' COLUMNS (v VARCHAR(4000) PATH '$')) t"
+}
+
 type oracle struct{}
 
 func (o oracle) IsOrdinalPlaceholderSupported() bool {
@@ -95,6 +332,85 @@ func (o oracle) WildcardRunes() []rune {
 	return []rune{'%', '_', '％', '＿'}
 }
 
+func (o oracle) QuoteIdentifier(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+func (o oracle) Concat(exprs ...string) string {
+	return strings.Join(exprs, " || ")
+}
+
+func (o oracle) RowNumPaginate(query, max, offset string) string {
+	return "SELECT * FROM (SELECT t" + Connector + ".*, ROWNUM rn" + Connector + " FROM (" + query + ") t" + Connector +
+		" WHERE ROWNUM <= " + max + ")" +
+		" WHERE rn" + Connector + " > " + offset
+}
+
+// Paginate uses `OFFSET ... FETCH` syntax that is supported on Oracle 12c or later.
+func (o oracle) Paginate(limit, offset string) string {
+	return "OFFSET " + offset + " ROWS FETCH NEXT " + limit + " ROWS ONLY"
+}
+
+func (o oracle) RequiresOrderBy() bool {
+	return false
+}
+
+// BoolLiteral returns `1` or `0`, because Oracle does not have boolean type in SQL.
+func (o oracle) BoolLiteral(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func (o oracle) StringLiteral(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+func (o oracle) TimeLiteral(t time.Time) string {
+	return "TIMESTAMP '" + t.Format("2006-01-02 15:04:05.999999999 -07:00") + "'"
+}
+
+func (o oracle) BytesLiteral(b []byte) string {
+	return "HEXTORAW('" + hex.EncodeToString(b) + "')"
+}
+
+func (o oracle) MaxBindParams() int {
+	return 65535
+}
+
+func (o oracle) MaxInListLength() int {
+	return 1000
+}
+
+func (o oracle) CurrentTimestamp() string {
+	return "SYSTIMESTAMP"
+}
+
+func (o oracle) CastType(typ string) string {
+	switch typ {
+	case "int":
+		return "NUMBER(19)"
+	case "float":
+		return "BINARY_DOUBLE"
+	case "time":
+		return "TIMESTAMP"
+	case "date":
+		return "DATE"
+	case "string":
+		return "VARCHAR2(4000)"
+	}
+	return ""
+}
+
+func (o oracle) ListValue(values []interface{}) (interface{}, error) {
+	return jsonList(values)
+}
+
+func (o oracle) ListExpression(placeholder string, values []interface{}) string {
+	return "SELECT v FROM JSON_TABLE(" + placeholder + ", '$[*]' COLUMNS (v VARCHAR2(4000) PATH '$'))"
+}
+
 type sqlserver struct{}
 
 func (s sqlserver) IsOrdinalPlaceholderSupported() bool {
@@ -116,3 +432,217 @@ func (s sqlserver) NamedPlaceholderPrefix() string {
 func (s sqlserver) WildcardRunes() []rune {
 	return []rune{'%', '_', '['}
 }
+
+func (s sqlserver) QuoteIdentifier(name string) string {
+	return "[" + strings.Replace(name, "]", "]]", -1) + "]"
+}
+
+func (s sqlserver) IdentifierQuotes() [][2]string {
+	return [][2]string{{"[", "]"}, {`"`, `"`}}
+}
+
+func (s sqlserver) BackslashEscape() bool {
+	return false
+}
+
+func (s sqlserver) HashComment() bool {
+	return false
+}
+
+func (s sqlserver) DollarQuote() bool {
+	return false
+}
+
+func (s sqlserver) Concat(exprs ...string) string {
+	return strings.Join(exprs, " + ")
+}
+
+func (s sqlserver) Paginate(limit, offset string) string {
+	return "OFFSET " + offset + " ROWS FETCH NEXT " + limit + " ROWS ONLY"
+}
+
+func (s sqlserver) RequiresOrderBy() bool {
+	return true
+}
+
+// BoolLiteral returns `1` or `0` for bit type.
+func (s sqlserver) BoolLiteral(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// StringLiteral returns unicode string literal.
+func (s sqlserver) StringLiteral(str string) string {
+	return "N'" + strings.Replace(str, "'", "''", -1) + "'"
+}
+
+// TimeLiteral returns `DATETIMEOFFSET` for keeping time zone offset.
+func (s sqlserver) TimeLiteral(t time.Time) string {
+	return "CAST('" + t.Format("2006-01-02 15:04:05.9999999 -07:00") + "' AS DATETIMEOFFSET)"
+}
+
+func (s sqlserver) BytesLiteral(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+func (s sqlserver) MaxBindParams() int {
+	return 2100
+}
+
+func (s sqlserver) MaxInListLength() int {
+	return 0
+}
+
+func (s sqlserver) CurrentTimestamp() string {
+	return "SYSDATETIME()"
+}
+
+func (s sqlserver) CastType(typ string) string {
+	switch typ {
+	case "int":
+		return "BIGINT"
+	case "float":
+		return "FLOAT"
+	case "bool":
+		return "BIT"
+	case "time":
+		return "DATETIME2"
+	case "date":
+		return "DATE"
+	case "string":
+		return "NVARCHAR(MAX)"
+	}
+	return ""
+}
+
+func (s sqlserver) ListValue(values []interface{}) (interface{}, error) {
+	return jsonList(values)
+}
+
+func (s sqlserver) ListExpression(placeholder string, values []interface{}) string {
+	return "SELECT value FROM OPENJSON(" + placeholder + ")"
+}
+
+type sqlite struct{}
+
+func (s sqlite) IsOrdinalPlaceholderSupported() bool {
+	return true
+}
+
+func (s sqlite) OrdinalPlaceholderPrefix() string {
+	return "?"
+}
+
+func (s sqlite) Placeholder() string {
+	return "?"
+}
+
+// NamedPlaceholderPrefix returns `:`.
+// SQLite also accepts `@` and `$` as prefix of named parameter.
+func (s sqlite) NamedPlaceholderPrefix() string {
+	return ":"
+}
+
+func (s sqlite) WildcardRunes() []rune {
+	return []rune{'%', '_'}
+}
+
+func (s sqlite) QuoteIdentifier(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+func (s sqlite) IdentifierQuotes() [][2]string {
+	return [][2]string{{`"`, `"`}, {"`", "`"}, {"[", "]"}}
+}
+
+func (s sqlite) BackslashEscape() bool {
+	return false
+}
+
+func (s sqlite) HashComment() bool {
+	return false
+}
+
+func (s sqlite) DollarQuote() bool {
+	return false
+}
+
+func (s sqlite) Concat(exprs ...string) string {
+	return strings.Join(exprs, " || ")
+}
+
+func (s sqlite) Paginate(limit, offset string) string {
+	return "LIMIT " + limit + " OFFSET " + offset
+}
+
+func (s sqlite) RequiresOrderBy() bool {
+	return false
+}
+
+// BoolLiteral returns `1` or `0`, because `TRUE` and `FALSE` are supported since SQLite 3.23.0.
+func (s sqlite) BoolLiteral(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func (s sqlite) StringLiteral(str string) string {
+	return "'" + strings.Replace(str, "'", "''", -1) + "'"
+}
+
+// TimeLiteral returns text, because SQLite does not have date and time type, stores those as text.
+func (s sqlite) TimeLiteral(t time.Time) string {
+	return "'" + t.Format("2006-01-02 15:04:05.999999-07:00") + "'"
+}
+
+func (s sqlite) BytesLiteral(b []byte) string {
+	return "X'" + hex.EncodeToString(b) + "'"
+}
+
+// MaxBindParams returns default limit of SQLite 3.32.0 or later.
+func (s sqlite) MaxBindParams() int {
+	return 32766
+}
+
+func (s sqlite) MaxInListLength() int {
+	return 0
+}
+
+func (s sqlite) CurrentTimestamp() string {
+	return "CURRENT_TIMESTAMP"
+}
+
+func (s sqlite) CastType(typ string) string {
+	switch typ {
+	case "int":
+		return "INTEGER"
+	case "float":
+		return "REAL"
+	case "string":
+		return "TEXT"
+	}
+	return ""
+}
+
+func (s sqlite) NullSafeEqual(left, right string) string {
+	return left + " IS " + right
+}
+
+func (s sqlite) NullSafeNotEqual(left, right string) string {
+	return left + " IS NOT " + right
+}
+
+func (s sqlite) RowValueSupported() bool {
+	return true
+}
+
+func (s sqlite) ListValue(values []interface{}) (interface{}, error) {
+	return jsonList(values)
+}
+
+func (s sqlite) ListExpression(placeholder string, values []interface{}) string {
+	return "SELECT value FROM json_each(" + placeholder + ")"
+}
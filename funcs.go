@@ -2,7 +2,9 @@ package sqlt
 
 import (
 	"fmt"
+	"math"
 	"reflect"
+	"strconv"
 	"strings"
 	"text/template"
 )
@@ -19,23 +21,18 @@ func (c *context) paramWithFunc(name string, fn func(string, interface{}) (strin
 		nm, v = fn(nm, v)
 	}
 
-	if c.named || c.dialect.IsOrdinalPlaceholderSupported() {
-		c.MergeArg(nm, v)
-	} else {
-		c.AddArg(nm, v)
-	}
-	return c.Placeholder(nm) + c.annotation(name)
+	return c.Bind(newArg(nm, p.path, v)) + c.annotation(name)
 }
 
 func (c *context) get(name string) interface{} {
 	p, err := c.Get(name)
 	if err != nil {
-		c.err = err
+		c.setError(err)
 		return nil
 	}
 	if s, ok := p.value.(string); ok {
-		if err = safe(s); err != nil {
-			c.err = fmt.Errorf("%q contains prohibited character(%s)", name, err.Error())
+		if err = c.sanitize(name, s); err != nil {
+			c.setError(err)
 			return nil
 		}
 	}
@@ -43,22 +40,169 @@ func (c *context) get(name string) interface{} {
 }
 
 func (c *context) out(name string) string {
+	s, raw, err := c.outValue(name)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	if raw {
+		return s + c.annotation("raw: "+name)
+	}
+	return s + c.annotation(name)
+}
+
+// outValue returns value for embedding to SQL directly, and returns true if value is Raw.
+func (c *context) outValue(name string) (string, bool, error) {
+	p, err := c.Get(name)
+	if err != nil {
+		return "", false, err
+	}
+
+	if r, ok := p.value.(Raw); ok {
+		return string(r), true, nil
+	}
+	s := fmt.Sprintf("%v", p.value)
+	if c.config.trustedOnly {
+		if !c.config.allowed(s) {
+			return "", false, fmt.Errorf("%q is not trusted value", name)
+		}
+		return s, false, nil
+	}
+	if err = c.sanitize(name, s); err != nil {
+		return "", false, err
+	}
+	return s, false, nil
+}
+
+func (c *context) num(name string) string {
+	p, err := c.Get(name)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+
+	rejected := &ErrRejected{Param: name, Policy: NumericSanitizer.Name(), Reason: "not numeric"}
+	v := reflect.ValueOf(p.value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10) + c.annotation(name)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10) + c.annotation(name)
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return c.errorOutput(rejected)
+		}
+		return strconv.FormatFloat(f, 'f', -1, v.Type().Bits()) + c.annotation(name)
+	case reflect.String:
+		if NumericSanitizer.Sanitize(v.String()) == nil {
+			return v.String() + c.annotation(name)
+		}
+	}
+	return c.errorOutput(rejected)
+}
+
+func (c *context) oneOf(name string, allowed ...string) string {
 	p, err := c.Get(name)
 	if err != nil {
 		return c.errorOutput(err)
 	}
 
 	s := fmt.Sprintf("%v", p.value)
-	if err = safe(s); err != nil {
-		return c.errorOutput(fmt.Errorf("%q contains prohibited character(%s)", name, err.Error()))
+	for _, a := range allowed {
+		if strings.EqualFold(s, a) {
+			return a + c.annotation(name)
+		}
+	}
+	return c.errorOutput(&ErrRejected{
+		Param:  name,
+		Policy: "oneOf",
+		Reason: fmt.Sprintf("not one of %s", strings.Join(allowed, ", ")),
+	})
+}
+
+func (c *context) lit(name string) string {
+	p, err := c.Get(name)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+
+	s, err := verifiedLiteral(c.dialect, p.value)
+	if err != nil {
+		return c.errorOutput(fmt.Errorf("%q cannot be rendered as literal(%s)", name, err.Error()))
 	}
 	return s + c.annotation(name)
 }
 
+// ident embeds value as quoted identifier. Qualified identifier (ex: `u.name`) is quoted for each part.
+func (c *context) ident(name string) string {
+	p, err := c.Get(name)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	s, ok := p.value.(string)
+	if !ok || s == "" {
+		return c.errorOutput(fmt.Errorf("%q is not identifier string", name))
+	}
+
+	q := identifierQuoterOf(c.dialect)
+	parts := strings.Split(s, ".")
+	for i, part := range parts {
+		parts[i] = q.QuoteIdentifier(part)
+	}
+	return strings.Join(parts, ".") + c.annotation(name)
+}
+
+func (c *context) concat(exprs ...interface{}) string {
+	ss := make([]string, len(exprs))
+	for i, e := range exprs {
+		ss[i] = fmt.Sprintf("%v", e)
+	}
+	return concatenatorOf(c.dialect).Concat(ss...)
+}
+
+func (c *context) currentTimestamp() string {
+	return currentTimestamperOf(c.dialect).CurrentTimestamp()
+}
+
 func (c *context) param(name string) string {
 	return c.paramWithFunc(name, nil)
 }
 
+// bindValue binds given value with generated name.
+// Same value is bound with same name, so it is merged on ordinal or named placeholder.
+func (c *context) bindValue(v interface{}) string {
+	for _, p := range c.values {
+		if reflect.DeepEqual(p.value, v) {
+			return c.Bind(p) + c.annotation(p.name)
+		}
+	}
+	// Suffix `value` is not generated from parameter by other funcs (ex: `in "pv"` generates `pv__1`).
+	p := newArg(c.freeName("pv"+Connector+"value%d", len(c.values)+1), "", v)
+	c.values = append(c.values, p)
+	return c.Bind(p) + c.annotation(p.name)
+}
+
+func (c *context) let(name string, v interface{}) string {
+	if strings.Contains(name, ".") {
+		return c.errorOutput(fmt.Errorf("%q is invalid local name", name))
+	}
+	// Generated name avoids collision of named argument with root parameter and redefined local parameter.
+	// Value defined in template has no path, because it is not parameter.
+	c.define(name, newArg(c.freeName(name+Connector+"let%d", len(c.locals)+1), "", v))
+	return ""
+}
+
+func (c *context) alias(name string, path string) string {
+	if strings.Contains(name, ".") {
+		return c.errorOutput(fmt.Errorf("%q is invalid local name", name))
+	}
+	p, err := c.Get(path)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	c.define(name, p)
+	return ""
+}
+
 func (c *context) in(name string) string {
 	p, err := c.Get(name)
 	if err != nil {
@@ -69,50 +213,41 @@ func (c *context) in(name string) string {
 	if v.Kind() != reflect.Slice {
 		return "(" + c.param(name) + ")"
 	}
+	if s, ok := c.bindList(name, p, v); ok {
+		return s
+	}
 
+	c.lists[name] = v.Len()
 	placeholders := make([]string, v.Len())
 	for i := 0; i < v.Len(); i++ {
 		sv := v.Index(i).Interface()
-		argName := fmt.Sprintf("%s%s%d", name, Connector, i+1)
-		if c.named || c.dialect.IsOrdinalPlaceholderSupported() {
-			c.MergeArg(argName, sv)
-		} else {
-			c.AddArg(argName, sv)
-		}
-		placeholders[i] = c.Placeholder(argName)
+		argName := fmt.Sprintf("%s%s%d", p.name, Connector, i+1)
+		placeholders[i] = c.Bind(newArg(argName, subPath(p.path, strconv.Itoa(i)), sv))
 	}
 	return "(" + strings.Join(placeholders, ", ") + ")" + c.annotation(name)
 }
 
 func (c *context) time() string {
 	name := "time" + Connector
-	tm := c.timer.time()
-	if c.named || c.dialect.IsOrdinalPlaceholderSupported() {
-		c.MergeArg(name, tm)
-	} else {
-		c.AddArg(name, tm)
-	}
-
-	return c.Placeholder(name)
+	return c.Bind(newArg(name, "", c.timer.time()))
 }
 
 func (c *context) now() string {
 	name := fmt.Sprintf("now%s%d", Connector, c.timer.nowCnt)
-	c.AddArg(name, c.timer.now())
+	c.AddArg(newArg(name, "", c.timer.now()))
 	return c.Placeholder(name)
 }
 
 func (c *context) prefix(name string) string {
-	return c.paramWithEscapeLike(name) + " || '%'" + escapeClause
+	return concatenatorOf(c.dialect).Concat(c.paramWithEscapeLike(name), "'%'") + escapeClause
 }
 
 func (c *context) infix(name string) string {
-	return "'%' || " + c.paramWithEscapeLike(name) + " || '%'" + escapeClause
+	return concatenatorOf(c.dialect).Concat("'%'", c.paramWithEscapeLike(name), "'%'") + escapeClause
 }
 
 func (c *context) suffix(name string) string {
-	return "'%' || " + c.paramWithEscapeLike(name) + escapeClause
-
+	return concatenatorOf(c.dialect).Concat("'%'", c.paramWithEscapeLike(name)) + escapeClause
 }
 
 func (c *context) name(args ...interface{}) string {
@@ -166,14 +301,39 @@ func (c *context) isEscapee(r rune) bool {
 	return false
 }
 
-func (c *context) funcMap(funcs map[string]interface{}) template.FuncMap {
-	fm := template.FuncMap(funcs)
+func (c *context) funcMap(funcs map[string]interface{}, binderFuncs map[string]BinderFunc) template.FuncMap {
+	fm := make(template.FuncMap)
+	for k, v := range funcs {
+		fm[k] = v
+	}
+	for k, v := range binderFuncs {
+		fm[k] = c.binderFunc(v)
+	}
 	fm["get"] = c.get
 	fm["out"] = c.out
 	fm["o"] = c.out
+	fm["num"] = c.num
+	fm["oneOf"] = c.oneOf
+	fm["lit"] = c.lit
+	fm["ident"] = c.ident
+	fm["concat"] = c.concat
+	fm["currentTimestamp"] = c.currentTimestamp
 	fm["param"] = c.param
 	fm["p"] = c.param
+	fm["pv"] = c.bindValue
+	fm["bind"] = c.bindValue
 	fm["in"] = c.in
+	fm["json"] = c.json
+	fm["cast"] = c.cast
+	fm["equal"] = c.equal
+	fm["notEqual"] = c.notEqual
+	fm["between"] = c.between
+	fm["criteria"] = c.criteria
+	fm["columns"] = c.columns
+	fm["insertValues"] = c.insertValues
+	fm["insert"] = c.insert
+	fm["let"] = c.let
+	fm["alias"] = c.alias
 	fm["time"] = c.time
 	fm["now"] = c.now
 	fm["prefix"] = c.prefix
@@ -181,6 +341,10 @@ func (c *context) funcMap(funcs map[string]interface{}) template.FuncMap {
 	fm["suffix"] = c.suffix
 	fm["escape"] = c.escapeLike
 	fm["name"] = c.name
+	fm["paginate"] = c.paginate
+	fm["limit"] = c.limit
+	fm["orderBy"] = c.orderBy
+	fm["seek"] = c.seek
 	return fm
 }
 
@@ -0,0 +1,83 @@
+package sqlt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// insertFields returns fields that are inserted.
+// Fields that have `auto` option and zero fields that have `omitempty` option are skipped.
+func (c *context) insertFields(name string) (*param, []taggedField, error) {
+	p, err := c.Get(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	fields, err := taggedFields(name, p.value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ifs := make([]taggedField, 0, len(fields))
+	for _, f := range fields {
+		if f.tag.has("auto") {
+			continue
+		}
+		if f.tag.has("omitempty") && !f.tag.has("time") && isZero(f.value) {
+			continue
+		}
+		ifs = append(ifs, f)
+	}
+	if len(ifs) == 0 {
+		return nil, nil, fmt.Errorf("%q has no column for insert", name)
+	}
+	return p, ifs, nil
+}
+
+func (c *context) columns(name string) string {
+	_, fields, err := c.insertFields(name)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	return "(" + strings.Join(columnsOf(fields), ", ") + ")"
+}
+
+func (c *context) insertValues(name string) string {
+	p, fields, err := c.insertFields(name)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	return "(" + strings.Join(c.valuesOf(name, p, fields), ", ") + ")"
+}
+
+func (c *context) insert(table string, name string) string {
+	p, fields, err := c.insertFields(name)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	return "INSERT INTO " + table +
+		" (" + strings.Join(columnsOf(fields), ", ") + ")" +
+		" VALUES (" + strings.Join(c.valuesOf(name, p, fields), ", ") + ")"
+}
+
+func columnsOf(fields []taggedField) []string {
+	cols := make([]string, len(fields))
+	for i, f := range fields {
+		cols[i] = f.tag.column
+	}
+	return cols
+}
+
+// valuesOf returns placeholders of fields. Fields that have `time` option are filled with value of `time` func.
+// Values are bound from fields directly, so nil pointer field is bound as NULL.
+func (c *context) valuesOf(name string, p *param, fields []taggedField) []string {
+	phs := make([]string, len(fields))
+	for i, f := range fields {
+		if f.tag.has("time") {
+			phs[i] = c.time()
+		} else {
+			arg := newArg(p.name+Connector+f.name, subPath(p.path, f.name), f.value.Interface())
+			phs[i] = c.Bind(arg) + c.annotation(name+"."+f.name)
+		}
+	}
+	return phs
+}
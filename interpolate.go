@@ -0,0 +1,59 @@
+package sqlt
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+)
+
+// Interpolate returns query that placeholders are replaced with literals of given args.
+// Args may be sql.NamedArg for named placeholders, then only named placeholders are replaced.
+// Returned query is for logging or debugging only, must not be executed.
+func Interpolate(d Dialect, query string, args []interface{}) string {
+	return interpolate(d, d.NamedPlaceholderPrefix(), query, args)
+}
+
+func interpolate(d Dialect, namedPrefix string, query string, args []interface{}) string {
+	named := make(map[string]interface{})
+	for _, arg := range args {
+		if na, ok := arg.(sql.NamedArg); ok {
+			named[na.Name] = na.Value
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	pos := 0
+	for _, tok := range scan(d, namedPrefix, len(named) > 0, query) {
+		switch tok.kind {
+		case ordinalToken:
+			if 0 < tok.index && tok.index <= len(args) {
+				buf.WriteString(interpolatedLiteral(d, args[tok.index-1]))
+				continue
+			}
+		case namedToken:
+			if v, ok := named[tok.name]; ok {
+				buf.WriteString(interpolatedLiteral(d, v))
+				continue
+			}
+		case positionalToken:
+			if pos < len(args) {
+				buf.WriteString(interpolatedLiteral(d, args[pos]))
+				pos++
+				continue
+			}
+		}
+		buf.WriteString(tok.text)
+	}
+	return buf.String()
+}
+
+func interpolatedLiteral(d Dialect, v interface{}) string {
+	if na, ok := v.(sql.NamedArg); ok {
+		v = na.Value
+	}
+	s, err := literal(d, v)
+	if err != nil {
+		return stringLiteral(d, fmt.Sprintf("%v", v))
+	}
+	return s
+}
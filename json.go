@@ -0,0 +1,34 @@
+package sqlt
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func (c *context) json(name string) string {
+	p, err := c.Get(name)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+
+	s, err := c.jsonValue(name, p.value)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	ph := c.Bind(newArg(p.name+Connector+"json", p.path, s))
+	return jsonCasterOf(c.dialect).JSONExpression(ph) + c.annotation(name)
+}
+
+func (c *context) jsonValue(name string, v interface{}) (string, error) {
+	if raw, ok := v.(json.RawMessage); ok {
+		if c.config.strictJSON && !json.Valid(raw) {
+			return "", fmt.Errorf("%q is invalid JSON", name)
+		}
+		return string(raw), nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("%q cannot be marshaled to JSON(%s)", name, err.Error())
+	}
+	return string(b), nil
+}
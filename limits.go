@@ -0,0 +1,123 @@
+package sqlt
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrTooManyParams is error that count of bind parameters exceeds maximum count of dialect.
+type ErrTooManyParams struct {
+	// Count is count of bind parameters.
+	Count int
+	// Max is maximum count of bind parameters of dialect.
+	Max int
+	// Params are template parameters that are expanded to bind parameters, in descending order of count.
+	// When no parameter is expanded, all referenced parameters.
+	Params []string
+}
+
+func (e *ErrTooManyParams) Error() string {
+	return fmt.Sprintf("%d bind parameters exceed maximum count %d (params: %s)", e.Count, e.Max, strings.Join(e.Params, ", "))
+}
+
+func jsonList(values []interface{}) (interface{}, error) {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// listElementType returns category of list elements (`int`, `float`, `bool`, `time` or `string`).
+// When elements are mixed, it returns `string`.
+func listElementType(values []interface{}) string {
+	typ := ""
+	for _, v := range values {
+		t := "string"
+		switch reflect.ValueOf(v).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			t = "int"
+		case reflect.Float32, reflect.Float64:
+			t = "float"
+		case reflect.Bool:
+			t = "bool"
+		case reflect.Invalid:
+			continue
+		}
+		if _, ok := v.(time.Time); ok {
+			t = "time"
+		}
+		if typ != "" && typ != t {
+			return "string"
+		}
+		typ = t
+	}
+	if typ == "" {
+		return "string"
+	}
+	return typ
+}
+
+// bindList binds whole list as one parameter when count of values exceeds limit of dialect.
+// It returns false when list should be expanded to placeholders.
+func (c *context) bindList(name string, p *param, v reflect.Value) (string, bool) {
+	max := inListLimiterOf(c.dialect).MaxInListLength()
+	overList := max > 0 && v.Len() > max
+	if !overList && !c.exceedsParamLimit(v.Len()) {
+		return "", false
+	}
+	lb, ok := c.dialect.(ListBinder)
+	if !ok || !c.config.listBinding {
+		if overList {
+			return c.errorOutput(fmt.Errorf("%q has %d values, but maximum length of IN list is %d", name, v.Len(), max)), true
+		}
+		return "", false
+	}
+
+	values := make([]interface{}, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		values[i] = v.Index(i).Interface()
+	}
+	lv, err := lb.ListValue(values)
+	if err != nil {
+		return c.errorOutput(err), true
+	}
+	ph := c.Bind(newArg(p.name+Connector+"list", p.path, lv))
+	return "(" + lb.ListExpression(ph, values) + ")" + c.annotation(name), true
+}
+
+func (c *context) exceedsParamLimit(n int) bool {
+	max := paramLimiterOf(c.dialect).MaxBindParams()
+	return max > 0 && len(c.args)+n > max
+}
+
+func (c *context) checkParamLimit() error {
+	max := paramLimiterOf(c.dialect).MaxBindParams()
+	if max == 0 || len(c.args) <= max {
+		return nil
+	}
+
+	params := make([]string, 0, len(c.lists))
+	for name := range c.lists {
+		params = append(params, name)
+	}
+	sort.Slice(params, func(i, j int) bool {
+		if c.lists[params[i]] == c.lists[params[j]] {
+			return params[i] < params[j]
+		}
+		return c.lists[params[i]] > c.lists[params[j]]
+	})
+	if len(params) == 0 {
+		params = c.refs
+	}
+	return &ErrTooManyParams{
+		Count:  len(c.args),
+		Max:    max,
+		Params: params,
+	}
+}
@@ -0,0 +1,152 @@
+package sqlt
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// literal returns SQL literal of given value for dialect.
+func literal(d Dialect, v interface{}) (string, error) {
+	if vr, ok := v.(driver.Valuer); ok {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return "NULL", nil
+		}
+		dv, err := vr.Value()
+		if err != nil {
+			return "", err
+		}
+		v = dv
+	}
+	switch tv := v.(type) {
+	case nil:
+		return "NULL", nil
+	case time.Time:
+		return timeLiteral(d, tv), nil
+	case []byte:
+		return bytesLiteral(d, tv), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return "NULL", nil
+		}
+		return literal(d, rv.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", fmt.Errorf("%v cannot be rendered as literal", f)
+		}
+		return strconv.FormatFloat(f, 'g', -1, rv.Type().Bits()), nil
+	case reflect.String:
+		return stringLiteral(d, rv.String()), nil
+	case reflect.Bool:
+		return boolLiteral(d, rv.Bool()), nil
+	}
+	return "", fmt.Errorf("%T cannot be rendered as literal", v)
+}
+
+// verifiedLiteral returns literal of given value, and verifies that string literal round-trips to original value.
+func verifiedLiteral(d Dialect, v interface{}) (string, error) {
+	lit, err := literal(d, v)
+	if err != nil {
+		return "", err
+	}
+	s, ok := stringValue(v)
+	if !ok {
+		return lit, nil
+	}
+	if strings.ContainsRune(s, 0) {
+		return "", fmt.Errorf("NUL character cannot be rendered as literal")
+	}
+	u, err := unquote(d, lit)
+	if err != nil {
+		return "", err
+	}
+	if u != s {
+		return "", fmt.Errorf("literal %s does not round-trip", lit)
+	}
+	return lit, nil
+}
+
+func stringValue(v interface{}) (string, bool) {
+	if vr, ok := v.(driver.Valuer); ok {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return "", false
+		}
+		dv, err := vr.Value()
+		if err != nil {
+			return "", false
+		}
+		v = dv
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.String {
+		return "", false
+	}
+	return rv.String(), true
+}
+
+func stringLiteral(d Dialect, s string) string {
+	return literalRendererOf(d).StringLiteral(s)
+}
+
+// unquote parses string literal that is rendered by stringLiteral.
+func unquote(d Dialect, lit string) (string, error) {
+	escapable := lexerOf(d).BackslashEscape()
+	if strings.HasPrefix(lit, "E'") {
+		escapable = true
+		lit = lit[1:]
+	} else if strings.HasPrefix(lit, "N'") {
+		lit = lit[1:]
+	}
+	if len(lit) < 2 || lit[0] != '\'' || lit[len(lit)-1] != '\'' {
+		return "", fmt.Errorf("%s is not string literal", lit)
+	}
+
+	buf := make([]byte, 0, len(lit))
+	body := lit[1 : len(lit)-1]
+	for i := 0; i < len(body); i++ {
+		switch {
+		case body[i] == '\'':
+			if i+1 >= len(body) || body[i+1] != '\'' {
+				return "", fmt.Errorf("%s has unescaped quotation", lit)
+			}
+			i++
+		case escapable && body[i] == '\\':
+			if i+1 >= len(body) {
+				return "", fmt.Errorf("%s has unterminated escape", lit)
+			}
+			i++
+		}
+		buf = append(buf, body[i])
+	}
+	return string(buf), nil
+}
+
+func boolLiteral(d Dialect, b bool) string {
+	return boolRendererOf(d).BoolLiteral(b)
+}
+
+func timeLiteral(d Dialect, t time.Time) string {
+	return literalRendererOf(d).TimeLiteral(t)
+}
+
+func bytesLiteral(d Dialect, b []byte) string {
+	return literalRendererOf(d).BytesLiteral(b)
+}
@@ -342,9 +342,9 @@ OR note4 = /*% p "note" %*/''`
 	eSQL := `
 SELECT *
 FROM items
-WHERE note1 LIKE '%' || ? || '%' ESCAPE '\'
-OR note2 LIKE ? || '%' ESCAPE '\'
-OR note3 LIKE '%' || ? ESCAPE '\'
+WHERE note1 LIKE CONCAT('%', ?, '%') ESCAPE '\'
+OR note2 LIKE CONCAT(?, '%') ESCAPE '\'
+OR note3 LIKE CONCAT('%', ?) ESCAPE '\'
 OR note4 = ?`
 	if eSQL != query {
 		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
@@ -384,9 +384,9 @@ OR note4 = /*% p "note" %*/''`
 	eSQL := `
 SELECT *
 FROM items
-WHERE note1 LIKE '%' || :note__esc || '%' ESCAPE '\'
-OR note2 LIKE :note__esc || '%' ESCAPE '\'
-OR note3 LIKE '%' || :note__esc ESCAPE '\'
+WHERE note1 LIKE CONCAT('%', :note__esc, '%') ESCAPE '\'
+OR note2 LIKE CONCAT(:note__esc, '%') ESCAPE '\'
+OR note3 LIKE CONCAT('%', :note__esc) ESCAPE '\'
 OR note4 = :note`
 	if eSQL != query {
 		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
@@ -420,9 +420,9 @@ OR note4 = /*% p "note" %*/''`
 	eSQL := `
 SELECT *
 FROM items
-WHERE note1 LIKE '%' || ? || '%' ESCAPE '\'
-OR note2 LIKE ? || '%' ESCAPE '\'
-OR note3 LIKE '%' || ? ESCAPE '\'
+WHERE note1 LIKE CONCAT('%', ?, '%') ESCAPE '\'
+OR note2 LIKE CONCAT(?, '%') ESCAPE '\'
+OR note3 LIKE CONCAT('%', ?) ESCAPE '\'
 OR note4 = ?`
 	if eSQL != query {
 		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
@@ -462,9 +462,9 @@ OR note4 = /*% p "note" %*/''`
 	eSQL := `
 SELECT *
 FROM items
-WHERE note1 LIKE '%' || :note || '%' ESCAPE '\'
-OR note2 LIKE :note || '%' ESCAPE '\'
-OR note3 LIKE '%' || :note ESCAPE '\'
+WHERE note1 LIKE CONCAT('%', :note, '%') ESCAPE '\'
+OR note2 LIKE CONCAT(:note, '%') ESCAPE '\'
+OR note3 LIKE CONCAT('%', :note) ESCAPE '\'
 OR note4 = :note`
 	if eSQL != query {
 		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
@@ -476,3 +476,50 @@ OR note4 = :note`
 		t.Errorf("exec failed: escaped value %v is invalid", args[0])
 	}
 }
+
+func TestMySQLPaginate(t *testing.T) {
+	s := `SELECT * FROM users ORDER BY id /*% paginate "page" "size" %*/`
+	query, args, err := sqlt.New(sqlt.MySQL).Exec(s, map[string]interface{}{
+		"page": 1,
+		"size": 20,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	eSQL := `SELECT * FROM users ORDER BY id LIMIT ? OFFSET ?`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 2 {
+		t.Errorf("exec failed: values should have 2 length, but got %v", args)
+	}
+	if isInvalidInt(args[0], 20) {
+		t.Errorf("exec failed: 1st value should be 20, but got %v", args)
+	}
+	if isInvalidInt(args[1], 0) {
+		t.Errorf("exec failed: 2nd value should be 0, but got %v", args)
+	}
+}
+
+func TestMySQLPaginateWithInvalidPage(t *testing.T) {
+	s := `SELECT * FROM users ORDER BY id /*% paginate "page" "size" %*/`
+	data := []struct {
+		page interface{}
+		tag  string
+	}{
+		{0, "zero page"},
+		{"first", "not integer"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			_, _, err := sqlt.New(sqlt.MySQL).Exec(s, map[string]interface{}{
+				"page": d.page,
+				"size": 20,
+			})
+			if err == nil {
+				t.Error("should raise error on invalid page")
+			}
+		})
+	}
+}
@@ -19,4 +19,79 @@ var (
 			conf.annotative = true
 		}
 	}
+
+	// RowNumPagination is option for paginating with ROWNUM on Oracle.
+	// Dialect must implement RowNumPaginator, otherwise `paginate` and `limit` raise error.
+	// Use this option for Oracle 11g or earlier that does not support `OFFSET ... FETCH` syntax.
+	RowNumPagination = func() Option {
+		return func(conf *config) {
+			conf.rowNum = true
+		}
+	}
+
+	// Profile is option for setting driver profile.
+	// Named placeholder is rendered with prefix of driver profile instead of dialect.
+	Profile = func(p DriverProfile) Option {
+		return func(conf *config) {
+			conf.profile = p
+		}
+	}
+
+	// ListBinding is option for binding whole slice of `in` as one parameter (JSON array)
+	// when count of values exceeds maximum count of bind parameters or IN list of dialect.
+	// Dialect must implement ListBinder.
+	ListBinding = func() Option {
+		return func(conf *config) {
+			conf.listBinding = true
+		}
+	}
+
+	// Verification is option for verifying integrity of rendered SQL.
+	// Rendered SQL must be single statement, must not contain comment except annotations and hints,
+	// and must have placeholders that match args.
+	Verification = func() Option {
+		return func(conf *config) {
+			conf.verify = true
+		}
+	}
+
+	// TrustedOnly is option for restricting `out` to Raw values or given allow-listed values.
+	TrustedOnly = func(allowList ...string) Option {
+		return func(conf *config) {
+			conf.trustedOnly = true
+			conf.allowList = allowList
+		}
+	}
+
+	// Sanitize is option for setting policy for checking value of `get` and `out`.
+	// Default policy is LegacySanitizer.
+	Sanitize = func(s Sanitizer) Option {
+		return func(conf *config) {
+			conf.sanitizer = s
+		}
+	}
+
+	// StrictJSON is option for validating json.RawMessage value of `json`.
+	// Without this option, json.RawMessage value is bound as it is.
+	StrictJSON = func() Option {
+		return func(conf *config) {
+			conf.strictJSON = true
+		}
+	}
+
+	// SQLCast is option for rendering SQL cast around placeholder of `cast` (ex: `CAST($1 AS BIGINT)`).
+	// Dialect must implement Caster, otherwise standard SQL type is used.
+	SQLCast = func() Option {
+		return func(conf *config) {
+			conf.sqlCast = true
+		}
+	}
+
+	// NullSafeEquality is option for rendering `equal` and `notEqual` with null-safe comparison (ex: `IS NOT DISTINCT FROM`).
+	// This option is ignored on dialect that does not implement NullSafeComparer.
+	NullSafeEquality = func() Option {
+		return func(conf *config) {
+			conf.nullSafe = true
+		}
+	}
 )
@@ -356,3 +356,79 @@ OR note4 = :note`
 		t.Errorf("exec failed: 1st value %v is invalid", args[0])
 	}
 }
+
+func TestOraclePaginate(t *testing.T) {
+	s := `SELECT * FROM users ORDER BY id /*% paginate "page" "size" %*/`
+	query, args, err := sqlt.New(sqlt.Oracle).Exec(s, map[string]interface{}{
+		"page": 2,
+		"size": 20,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	eSQL := `SELECT * FROM users ORDER BY id OFFSET :1 ROWS FETCH NEXT :2 ROWS ONLY`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 2 {
+		t.Errorf("exec failed: values should have 2 length, but got %v", args)
+	}
+}
+
+func TestOraclePaginateWithRowNum(t *testing.T) {
+	s := `SELECT * FROM users WHERE age > /*% p "age" %*/20 ORDER BY id /*% paginate "page" "size" %*/`
+	query, args, err := sqlt.New(sqlt.Oracle).WithOptions(sqlt.RowNumPagination()).Exec(s, map[string]interface{}{
+		"age":  30,
+		"page": 3,
+		"size": 20,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	eSQL := `SELECT * FROM (SELECT t__.*, ROWNUM rn__ FROM (SELECT * FROM users WHERE age > :1 ORDER BY id) t__ WHERE ROWNUM <= :2) WHERE rn__ > :3`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 3 {
+		t.Errorf("exec failed: values should have 3 length, but got %v", args)
+	}
+	if isInvalidInt(args[1], 60) {
+		t.Errorf("exec failed: 2nd value should be 60, but got %v", args)
+	}
+	if isInvalidInt(args[2], 40) {
+		t.Errorf("exec failed: 3rd value should be 40, but got %v", args)
+	}
+}
+
+func TestPaginateWithRowNumOnUnsupportedDialect(t *testing.T) {
+	s := `SELECT * FROM users ORDER BY id /*% paginate "page" "size" %*/`
+	_, _, err := sqlt.New(sqlt.Postgres).WithOptions(sqlt.RowNumPagination()).Exec(s, map[string]interface{}{
+		"page": 3,
+		"size": 20,
+	})
+	if err == nil {
+		t.Error("should raise error when dialect does not support ROWNUM pagination")
+	}
+}
+
+func TestOracleInListLimit(t *testing.T) {
+	s := `SELECT * FROM users WHERE id IN /*% in "ids" %*/(1)`
+	ids := make([]int, 1001)
+	if _, _, err := sqlt.New(sqlt.Oracle).Exec(s, singleMap("ids", ids)); err == nil {
+		t.Error("should raise error when IN list exceeds maximum length")
+	}
+
+	query, args, err := sqlt.New(sqlt.Oracle).Exec(s, singleMap("ids", ids), sqlt.ListBinding())
+	if err != nil {
+		t.Fatal(err)
+	}
+	eSQL := `SELECT * FROM users WHERE id IN (SELECT v FROM JSON_TABLE(:1, '$[*]' COLUMNS (v VARCHAR2(4000) PATH '$')))`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 1 {
+		t.Errorf("exec failed: values should have 1 length, but got %v", args)
+	}
+}
@@ -0,0 +1,149 @@
+package sqlt
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	limitMarker  = "\x00limit\x00"
+	offsetMarker = "\x00offset\x00"
+)
+
+var orderByRegex = regexp.MustCompile(`(?i)\bORDER\s+BY\b`)
+
+// pagination keeps values for wrapping query with ROWNUM.
+type pagination struct {
+	limit  *param
+	offset *param
+}
+
+func (c *context) paginate(page, size string) string {
+	pp, err := c.Get(page)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	sp, err := c.Get(size)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	pn, err := toInt(pp.value, page)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	sn, err := toInt(sp.value, size)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	if pn < 1 {
+		return c.errorOutput(fmt.Errorf("%q should be greater than 0", page))
+	}
+	if sn < 0 {
+		return c.errorOutput(fmt.Errorf("%q should not be negative", size))
+	}
+	return c.pagingClause(&pagination{
+		limit:  newArg(sp.name, sp.path, sn),
+		offset: newArg(pp.name+Connector+"offset", pp.path, (pn-1)*sn),
+	}, size, page)
+}
+
+func (c *context) limit(limit, offset string) string {
+	lp, err := c.Get(limit)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	op, err := c.Get(offset)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	ln, err := toInt(lp.value, limit)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	on, err := toInt(op.value, offset)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	if ln < 0 {
+		return c.errorOutput(fmt.Errorf("%q should not be negative", limit))
+	}
+	if on < 0 {
+		return c.errorOutput(fmt.Errorf("%q should not be negative", offset))
+	}
+	return c.pagingClause(&pagination{
+		limit:  newArg(lp.name, lp.path, ln),
+		offset: newArg(op.name, op.path, on),
+	}, limit, offset)
+}
+
+func (c *context) pagingClause(pg *pagination, limitAnn, offsetAnn string) string {
+	if c.counting {
+		return ""
+	}
+	if c.config.rowNum {
+		if _, ok := c.dialect.(RowNumPaginator); !ok {
+			return c.errorOutput(fmt.Errorf("dialect does not support ROWNUM pagination"))
+		}
+		c.pagination = pg
+		return ""
+	}
+
+	pr := paginatorOf(c.dialect)
+	if pr.RequiresOrderBy() && !orderByRegex.MatchString(c.buf.String()) {
+		return c.errorOutput(fmt.Errorf("ORDER BY is required for pagination"))
+	}
+	// Bind values in order of appearance for positional placeholder.
+	s := pr.Paginate(limitMarker, offsetMarker)
+	if strings.Index(s, offsetMarker) < strings.Index(s, limitMarker) {
+		s = strings.Replace(s, offsetMarker, c.Bind(pg.offset)+c.annotation(offsetAnn), 1)
+		return strings.Replace(s, limitMarker, c.Bind(pg.limit)+c.annotation(limitAnn), 1)
+	}
+	s = strings.Replace(s, limitMarker, c.Bind(pg.limit)+c.annotation(limitAnn), 1)
+	return strings.Replace(s, offsetMarker, c.Bind(pg.offset)+c.annotation(offsetAnn), 1)
+}
+
+func (c *context) orderBy(name string) string {
+	if c.counting {
+		return ""
+	}
+	s, raw, err := c.outValue(name)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	if raw {
+		return "ORDER BY " + s + c.annotation("raw: "+name)
+	}
+	return "ORDER BY " + s + c.annotation(name)
+}
+
+func (c *context) wrapRowNum(s string) string {
+	pg := c.pagination
+	max := newArg(pg.limit.name+Connector+"max", pg.limit.path, pg.limit.value.(int)+pg.offset.value.(int))
+	// Bind values in order of appearance for positional placeholder.
+	q := c.dialect.(RowNumPaginator).RowNumPaginate(strings.TrimSpace(s), limitMarker, offsetMarker)
+	if strings.Index(q, offsetMarker) < strings.Index(q, limitMarker) {
+		q = strings.Replace(q, offsetMarker, c.Bind(pg.offset), 1)
+		return strings.Replace(q, limitMarker, c.Bind(max), 1)
+	}
+	q = strings.Replace(q, limitMarker, c.Bind(max), 1)
+	return strings.Replace(q, offsetMarker, c.Bind(pg.offset), 1)
+}
+
+func toInt(v interface{}, name string) (int, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(rv.Uint()), nil
+	case reflect.String:
+		n, err := strconv.Atoi(rv.String())
+		if err == nil {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("%q is not integer", name)
+}
@@ -1,6 +1,8 @@
 package sqlt_test
 
 import (
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/pinzolo/sqlt"
@@ -356,3 +358,92 @@ OR note4 = :note`
 		t.Errorf("exec failed: 1st value %v is invalid", args[0])
 	}
 }
+
+func TestPostgresPaginate(t *testing.T) {
+	s := `SELECT * FROM users ORDER BY id /*% paginate "page" "size" %*/`
+	query, args, err := sqlt.New(sqlt.Postgres).Exec(s, map[string]interface{}{
+		"page": 3,
+		"size": 20,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	eSQL := `SELECT * FROM users ORDER BY id LIMIT $1 OFFSET $2`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 2 {
+		t.Errorf("exec failed: values should have 2 length, but got %v", args)
+	}
+	if isInvalidInt(args[0], 20) {
+		t.Errorf("exec failed: 1st value should be 20, but got %v", args)
+	}
+	if isInvalidInt(args[1], 40) {
+		t.Errorf("exec failed: 2nd value should be 40, but got %v", args)
+	}
+}
+
+func TestPostgresLimitNamed(t *testing.T) {
+	s := `SELECT * FROM users ORDER BY id /*% limit "limit" "offset" %*/`
+	query, args, err := sqlt.New(sqlt.Postgres).ExecNamed(s, map[string]interface{}{
+		"limit":  10,
+		"offset": 30,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	eSQL := `SELECT * FROM users ORDER BY id LIMIT :limit OFFSET :offset`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 2 {
+		t.Errorf("exec failed: values should have 2 length, but got %v", args)
+	}
+	if isInvalidIntArg(args[0], "limit", 10) {
+		t.Errorf("exec failed: values should have limit = 10, but got %v", args)
+	}
+	if isInvalidIntArg(args[1], "offset", 30) {
+		t.Errorf("exec failed: values should have offset = 30, but got %v", args)
+	}
+}
+
+func TestPostgresListBinding(t *testing.T) {
+	ids := make([]int, 70000)
+	names := make([]string, 70000)
+	for i := range ids {
+		ids[i] = i
+		names[i] = "user" + strconv.Itoa(i)
+	}
+	data := []struct {
+		list   interface{}
+		sql    string
+		prefix string
+		tag    string
+	}{
+		{ids, `SELECT * FROM users WHERE name = $1 AND id IN (SELECT jsonb_array_elements_text($2::jsonb)::bigint)`, `[0,1,2,`, "int"},
+		{names, `SELECT * FROM users WHERE name = $1 AND id IN (SELECT jsonb_array_elements_text($2::jsonb)::text)`, `["user0","user1",`, "string"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			s := `SELECT * FROM users WHERE name = /*% p "name" %*/'' AND id IN /*% in "ids" %*/(1)`
+			query, args, err := sqlt.New(sqlt.Postgres).WithOptions(sqlt.ListBinding()).Exec(s, map[string]interface{}{
+				"name": "Alex",
+				"ids":  d.list,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.sql != query {
+				t.Errorf("exec failed: expected %s, but got %s", d.sql, query)
+			}
+			if len(args) != 2 {
+				t.Fatalf("exec failed: values should have 2 length, but got %d", len(args))
+			}
+			if s, ok := args[1].(string); !ok || !strings.HasPrefix(s, d.prefix) {
+				t.Errorf("exec failed: 2nd value should be JSON array, but got %.20v", args[1])
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package sqlt
+
+// DriverProfile resolves placeholder syntax that depends on database driver, not on SQL dialect.
+type DriverProfile interface {
+	// NamedPlaceholderPrefix returns prefix of named placeholder.
+	// Empty string means that driver does not support named placeholder.
+	NamedPlaceholderPrefix() string
+}
+
+var (
+	// PgxProfile is driver profile for github.com/jackc/pgx (`@name` for pgx.NamedArgs).
+	PgxProfile DriverProfile = driverProfile{namedPrefix: "@"}
+	// PqProfile is driver profile for github.com/lib/pq.
+	// This driver does not support named placeholder, so use SqlxProfile with github.com/jmoiron/sqlx for named parameters.
+	PqProfile DriverProfile = driverProfile{namedPrefix: ""}
+	// SqlxProfile is driver profile for github.com/jmoiron/sqlx (`:name`).
+	SqlxProfile DriverProfile = driverProfile{namedPrefix: ":"}
+	// MssqlProfile is driver profile for github.com/denisenkom/go-mssqldb (`@name`).
+	MssqlProfile DriverProfile = driverProfile{namedPrefix: "@"}
+	// GodrorProfile is driver profile for github.com/godror/godror (`:name`).
+	GodrorProfile DriverProfile = driverProfile{namedPrefix: ":"}
+	// MysqlProfile is driver profile for github.com/go-sql-driver/mysql.
+	// This driver does not support named placeholder.
+	MysqlProfile DriverProfile = driverProfile{namedPrefix: ""}
+)
+
+type driverProfile struct {
+	namedPrefix string
+}
+
+func (p driverProfile) NamedPlaceholderPrefix() string {
+	return p.namedPrefix
+}
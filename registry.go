@@ -0,0 +1,87 @@
+package sqlt
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	dialectsMu sync.RWMutex
+	dialects   = map[string]Dialect{
+		"postgres":  Postgres,
+		"pgx":       Postgres,
+		"mysql":     MySQL,
+		"oracle":    Oracle,
+		"godror":    Oracle,
+		"sqlserver": SQLServer,
+		"mssql":     SQLServer,
+		"sqlite3":   SQLite,
+	}
+	// driverPackages are package paths of well-known drivers and its registered name.
+	driverPackages = map[string]string{
+		"github.com/lib/pq":                "postgres",
+		"github.com/jackc/pgx":             "pgx",
+		"github.com/go-sql-driver/mysql":   "mysql",
+		"github.com/godror/godror":         "godror",
+		"gopkg.in/goracle.v2":              "godror",
+		"github.com/mattn/go-oci8":         "oracle",
+		"github.com/sijms/go-ora":          "oracle",
+		"github.com/denisenkom/go-mssqldb": "sqlserver",
+		"github.com/microsoft/go-mssqldb":  "sqlserver",
+		"github.com/mattn/go-sqlite3":      "sqlite3",
+		"modernc.org/sqlite":               "sqlite3",
+	}
+)
+
+// RegisterDialect registers dialect with driver name.
+// Registered dialect overwrites existing dialect that has same name.
+// This function can be called from `init` of third-party dialect package.
+func RegisterDialect(name string, d Dialect) {
+	if d == nil {
+		panic("sqlt: registering dialect is nil")
+	}
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+	dialects[name] = d
+}
+
+// DialectFor returns dialect that is registered with given driver name.
+func DialectFor(driverName string) (Dialect, error) {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+	d, ok := dialects[driverName]
+	if !ok {
+		return nil, fmt.Errorf("%q is unknown driver name", driverName)
+	}
+	return d, nil
+}
+
+// DialectOf detects dialect from driver of given database.
+// Driver is detected from its package path. When driver is not well-known,
+// dialect that is registered with the last element of package path (ex: `pq` for `github.com/lib/pq`) is used.
+func DialectOf(db *sql.DB) (Dialect, error) {
+	return dialectOfDriver(db.Driver())
+}
+
+func dialectOfDriver(drv driver.Driver) (Dialect, error) {
+	t := reflect.TypeOf(drv)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	pkg := t.PkgPath()
+	for p, name := range driverPackages {
+		if pkg == p || strings.HasPrefix(pkg, p+"/") {
+			return DialectFor(name)
+		}
+	}
+	d, err := DialectFor(path.Base(pkg))
+	if err != nil {
+		return nil, fmt.Errorf("dialect for driver %s is unknown", t.String())
+	}
+	return d, nil
+}
@@ -0,0 +1,85 @@
+package sqlt_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/pinzolo/sqlt"
+)
+
+type testDriver struct{}
+
+func (d testDriver) Open(name string) (driver.Conn, error) {
+	return nil, errors.New("not implemented")
+}
+
+func init() {
+	sql.Register("sqlt-test", testDriver{})
+}
+
+func TestDialectFor(t *testing.T) {
+	data := []struct {
+		name    string
+		dialect sqlt.Dialect
+	}{
+		{"postgres", sqlt.Postgres},
+		{"pgx", sqlt.Postgres},
+		{"mysql", sqlt.MySQL},
+		{"oracle", sqlt.Oracle},
+		{"godror", sqlt.Oracle},
+		{"sqlserver", sqlt.SQLServer},
+		{"mssql", sqlt.SQLServer},
+		{"sqlite3", sqlt.SQLite},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			dialect, err := sqlt.DialectFor(d.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dialect != d.dialect {
+				t.Errorf("dialect for %q should be %T, but got %T", d.name, d.dialect, dialect)
+			}
+		})
+	}
+}
+
+func TestDialectForUnknownDriver(t *testing.T) {
+	if _, err := sqlt.DialectFor("unknown"); err == nil {
+		t.Error("should raise error on unknown driver name")
+	}
+}
+
+func TestRegisterDialect(t *testing.T) {
+	sqlt.RegisterDialect("custom", sqlt.MySQL)
+	dialect, err := sqlt.DialectFor("custom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dialect != sqlt.MySQL {
+		t.Errorf("registered dialect should be returned, but got %T", dialect)
+	}
+}
+
+func TestDialectOf(t *testing.T) {
+	db, err := sql.Open("sqlt-test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err = sqlt.DialectOf(db); err == nil {
+		t.Error("should raise error on unknown driver")
+	}
+
+	sqlt.RegisterDialect("sqlt_test", sqlt.Postgres)
+	dialect, err := sqlt.DialectOf(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dialect != sqlt.Postgres {
+		t.Errorf("dialect registered with driver package name should be returned, but got %T", dialect)
+	}
+}
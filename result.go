@@ -0,0 +1,70 @@
+package sqlt
+
+import "database/sql"
+
+// Result is result of executing template.
+type Result struct {
+	// SQL is generated SQL.
+	SQL string
+	// Args are arguments for generated SQL.
+	Args []interface{}
+	// NamedArgs are arguments for generated SQL with its name.
+	NamedArgs []sql.NamedArg
+	// Bindings are placeholders and its source parameters.
+	// Each element corresponds to the element of Args with same index.
+	Bindings []Binding
+	// Params are names of parameters that are referenced in template.
+	Params []string
+	// Warnings are errors that are occurred in template.
+	// These errors are annotated to SQL in annotative mode.
+	Warnings    []error
+	dialect     Dialect
+	named       bool
+	namedPrefix string
+}
+
+// Binding is relation between placeholder and source parameter.
+type Binding struct {
+	// Placeholder in generated SQL (ex: `$3`).
+	Placeholder string
+	// Name of argument (ex: `ids__3`).
+	Name string
+	// Path of source parameter (ex: `ids.2`).
+	// Path is empty when value is not from parameter.
+	Path string
+}
+
+// String returns SQL that placeholders are replaced with literals of args.
+// This is for logging or debugging only, do not execute returned SQL.
+func (r *Result) String() string {
+	if !r.named {
+		return interpolate(r.dialect, r.namedPrefix, r.SQL, r.Args)
+	}
+	args := make([]interface{}, len(r.NamedArgs))
+	for i, arg := range r.NamedArgs {
+		args[i] = arg
+	}
+	return interpolate(r.dialect, r.namedPrefix, r.SQL, args)
+}
+
+func (c *context) result(s string) *Result {
+	bindings := make([]Binding, len(c.args))
+	for i, arg := range c.args {
+		bindings[i] = Binding{
+			Placeholder: c.Placeholder(arg.name),
+			Name:        arg.name,
+			Path:        arg.path,
+		}
+	}
+	return &Result{
+		SQL:         s,
+		Args:        c.Args(),
+		NamedArgs:   c.NamedArgs(),
+		Bindings:    bindings,
+		Params:      c.refs,
+		Warnings:    c.warnings,
+		dialect:     c.dialect,
+		named:       c.named,
+		namedPrefix: c.namedPlaceholderPrefix(),
+	}
+}
@@ -0,0 +1,126 @@
+package sqlt
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Sanitizer is policy for checking value that is embedded to SQL directly by `get` and `out`.
+type Sanitizer interface {
+	// Name returns name of policy.
+	Name() string
+	// Sanitize returns error that describes reason if given value is rejected.
+	Sanitize(s string) error
+}
+
+// ErrRejected is error that value is rejected by Sanitizer.
+type ErrRejected struct {
+	// Param is name of rejected parameter.
+	Param string
+	// Policy is name of Sanitizer that rejects value.
+	Policy string
+	// Reason is reason of rejection.
+	Reason string
+}
+
+func (e *ErrRejected) Error() string {
+	return fmt.Sprintf("%q is rejected by %s policy: %s", e.Param, e.Policy, e.Reason)
+}
+
+var (
+	// LegacySanitizer rejects value that contains single quotation, semi colon or comment.
+	// This is default policy.
+	LegacySanitizer Sanitizer = legacySanitizer{}
+	// IdentifierSanitizer accepts only identifier or qualified identifier (ex: `u.name`).
+	IdentifierSanitizer Sanitizer = regexpSanitizer{
+		name:   "identifier",
+		regex:  regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*(\.[A-Za-z_][A-Za-z0-9_$]*)*$`),
+		reason: "not identifier",
+	}
+	// NumericSanitizer accepts only integer or decimal.
+	NumericSanitizer Sanitizer = regexpSanitizer{
+		name:   "numeric",
+		regex:  regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?$`),
+		reason: "not numeric",
+	}
+)
+
+// KeywordSanitizer accepts only value that consists of given keywords (case-insensitive).
+// Keywords in value are separated by white spaces or commas.
+func KeywordSanitizer(keywords ...string) Sanitizer {
+	m := make(map[string]bool)
+	for _, k := range keywords {
+		m[strings.ToUpper(k)] = true
+	}
+	return keywordSanitizer{keywords: m}
+}
+
+type legacySanitizer struct{}
+
+func (s legacySanitizer) Name() string {
+	return "legacy"
+}
+
+func (s legacySanitizer) Sanitize(v string) error {
+	if err := safe(v); err != nil {
+		return fmt.Errorf("prohibited character(%s)", err.Error())
+	}
+	return nil
+}
+
+type regexpSanitizer struct {
+	name   string
+	regex  *regexp.Regexp
+	reason string
+}
+
+func (s regexpSanitizer) Name() string {
+	return s.name
+}
+
+func (s regexpSanitizer) Sanitize(v string) error {
+	if !s.regex.MatchString(v) {
+		return errors.New(s.reason)
+	}
+	return nil
+}
+
+type keywordSanitizer struct {
+	keywords map[string]bool
+}
+
+func (s keywordSanitizer) Name() string {
+	return "keyword"
+}
+
+func (s keywordSanitizer) Sanitize(v string) error {
+	words := strings.FieldsFunc(v, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\r' || r == '\n'
+	})
+	if len(words) == 0 {
+		return errors.New("empty value")
+	}
+	for _, w := range words {
+		if !s.keywords[strings.ToUpper(w)] {
+			return errors.New("not allowed keyword")
+		}
+	}
+	return nil
+}
+
+func (c *context) sanitize(name string, s string) error {
+	sz := c.config.sanitizer
+	if sz == nil {
+		sz = LegacySanitizer
+	}
+	if err := sz.Sanitize(s); err != nil {
+		return &ErrRejected{
+			Param:  name,
+			Policy: sz.Name(),
+			Reason: err.Error(),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package sqlt
+
+import (
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	textToken tokenKind = iota
+	// quotedToken is string literal or quoted identifier.
+	quotedToken
+	commentToken
+	ordinalToken
+	namedToken
+	positionalToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// index is index of ordinal placeholder.
+	index int
+	// name is name of named placeholder.
+	name string
+}
+
+// scan splits query to tokens for dialect.
+// When named is true, only named placeholders are scanned, because ordinal placeholder may overlap named one (ex: `@p1` on SQL Server).
+func scan(d Dialect, namedPrefix string, named bool, query string) []token {
+	tokens := make([]token, 0)
+	start := 0
+	flush := func(i int) {
+		if start < i {
+			tokens = append(tokens, token{kind: textToken, text: query[start:i]})
+		}
+	}
+	for i := 0; i < len(query); {
+		rest := query[i:]
+		tok, n := scanToken(d, namedPrefix, named, rest)
+		if n == 0 {
+			i++
+			continue
+		}
+		flush(i)
+		tok.text = rest[:n]
+		tokens = append(tokens, tok)
+		i += n
+		start = i
+	}
+	flush(len(query))
+	return tokens
+}
+
+// scanToken returns token at head of s and its length.
+// It returns 0 as length when s starts with plain text.
+func scanToken(d Dialect, namedPrefix string, named bool, s string) (token, int) {
+	lx := lexerOf(d)
+	for _, q := range lx.IdentifierQuotes() {
+		if strings.HasPrefix(s, q[0]) {
+			return token{kind: quotedToken}, enclosedLength(s, q[0], q[1])
+		}
+	}
+	switch {
+	case strings.HasPrefix(s, "'") || strings.HasPrefix(s, `"`):
+		if lx.BackslashEscape() {
+			return token{kind: quotedToken}, escapedLength(s, s[0])
+		}
+		return token{kind: quotedToken}, enclosedLength(s, s[:1], s[:1])
+	case lx.DollarQuote() && strings.HasPrefix(s, "$"):
+		if n := dollarQuotedLength(s); n > 0 {
+			return token{kind: quotedToken}, n
+		}
+	case strings.HasPrefix(s, "--"):
+		return token{kind: commentToken}, enclosedLength(s, "--", "\n")
+	case lx.HashComment() && strings.HasPrefix(s, "#"):
+		return token{kind: commentToken}, enclosedLength(s, "#", "\n")
+	case strings.HasPrefix(s, "/*"):
+		return token{kind: commentToken}, enclosedLength(s, "/*", "*/")
+	case strings.HasPrefix(s, "::"):
+		// PostgreSQL style cast is not named placeholder.
+		return token{kind: textToken}, 2
+	}
+
+	if !named && d.IsOrdinalPlaceholderSupported() {
+		prefix := d.OrdinalPlaceholderPrefix()
+		if strings.HasPrefix(s, prefix) {
+			digits := leadingLength(s[len(prefix):], isDigit)
+			// Ordinal placeholder is not followed by identifier character (ex: `@p1x` is not ordinal).
+			if digits > 0 && leadingLength(s[len(prefix)+digits:], isIdentChar) == 0 {
+				n, _ := strconv.Atoi(s[len(prefix) : len(prefix)+digits])
+				return token{kind: ordinalToken, index: n}, len(prefix) + digits
+			}
+		}
+	}
+	if namedPrefix != "" && strings.HasPrefix(s, namedPrefix) {
+		l := leadingLength(s[len(namedPrefix):], isIdentChar)
+		if l > 0 {
+			return token{kind: namedToken, name: s[len(namedPrefix) : len(namedPrefix)+l]}, len(namedPrefix) + l
+		}
+	}
+	if ph := d.Placeholder(); !named && ph != "" && strings.HasPrefix(s, ph) {
+		return token{kind: positionalToken}, len(ph)
+	}
+	return token{}, 0
+}
+
+func enclosedLength(s, begin, end string) int {
+	i := strings.Index(s[len(begin):], end)
+	if i < 0 {
+		return len(s)
+	}
+	return len(begin) + i + len(end)
+}
+
+// escapedLength returns length of string literal that may contain backslash escaped quote.
+func escapedLength(s string, quote byte) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// dollarQuotedLength returns length of dollar quoted string literal (ex: `$tag$...$tag$`).
+// It returns 0 when s does not start with dollar quote (ex: `$1`).
+func dollarQuotedLength(s string) int {
+	l := leadingLength(s[1:], isIdentChar)
+	if l > 0 && isDigit(s[1]) || 1+l >= len(s) || s[1+l] != '$' {
+		return 0
+	}
+	tag := s[:l+2]
+	return enclosedLength(s, tag, tag)
+}
+
+func leadingLength(s string, fn func(byte) bool) int {
+	i := 0
+	for i < len(s) && fn(s[i]) {
+		i++
+	}
+	return i
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+func isIdentChar(b byte) bool {
+	return isDigit(b) || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || b == '_'
+}
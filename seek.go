@@ -0,0 +1,204 @@
+package sqlt
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is error that cursor of `seek` cannot be decoded.
+// Error message does not contain cursor, because cursor is given by client.
+type ErrInvalidCursor struct {
+	// Param is name of parameter.
+	Param string
+	// Err is cause of failure.
+	Err error
+}
+
+func (e *ErrInvalidCursor) Error() string {
+	return fmt.Sprintf("%q is invalid cursor", e.Param)
+}
+
+// cursorValue is encoded value in cursor with its type.
+type cursorValue struct {
+	Type  string `json:"t"`
+	Value string `json:"v,omitempty"`
+}
+
+// EncodeCursor encodes given values (generally values of sort key columns of last row) to opaque cursor string.
+// Supported types are integer, float, string, bool, time.Time, []byte, nil and driver.Valuer returns those.
+func EncodeCursor(values ...interface{}) (string, error) {
+	cvs := make([]cursorValue, len(values))
+	for i, v := range values {
+		cv, err := newCursorValue(v)
+		if err != nil {
+			return "", err
+		}
+		cvs[i] = cv
+	}
+	b, err := json.Marshal(cvs)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor decodes given cursor string that is encoded by EncodeCursor.
+// Integer is decoded to int64, unsigned integer to uint64 and float to float64.
+func DecodeCursor(cursor string) ([]interface{}, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %s", err.Error())
+	}
+	var cvs []cursorValue
+	if err = json.Unmarshal(b, &cvs); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %s", err.Error())
+	}
+	values := make([]interface{}, len(cvs))
+	for i, cv := range cvs {
+		v, err := cv.decode()
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor: %s", err.Error())
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func newCursorValue(v interface{}) (cursorValue, error) {
+	if vr, ok := v.(driver.Valuer); ok {
+		dv, err := vr.Value()
+		if err != nil {
+			return cursorValue{}, err
+		}
+		v = dv
+	}
+	switch tv := v.(type) {
+	case nil:
+		return cursorValue{Type: "n"}, nil
+	case time.Time:
+		return cursorValue{Type: "t", Value: tv.Format(time.RFC3339Nano)}, nil
+	case []byte:
+		return cursorValue{Type: "x", Value: base64.StdEncoding.EncodeToString(tv)}, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return cursorValue{Type: "n"}, nil
+		}
+		return newCursorValue(rv.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cursorValue{Type: "i", Value: strconv.FormatInt(rv.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cursorValue{Type: "u", Value: strconv.FormatUint(rv.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return cursorValue{Type: "f", Value: strconv.FormatFloat(rv.Float(), 'g', -1, 64)}, nil
+	case reflect.String:
+		return cursorValue{Type: "s", Value: rv.String()}, nil
+	case reflect.Bool:
+		return cursorValue{Type: "b", Value: strconv.FormatBool(rv.Bool())}, nil
+	}
+	return cursorValue{}, fmt.Errorf("%T is not supported for cursor", v)
+}
+
+func (cv cursorValue) decode() (interface{}, error) {
+	switch cv.Type {
+	case "n":
+		return nil, nil
+	case "t":
+		return time.Parse(time.RFC3339Nano, cv.Value)
+	case "x":
+		return base64.StdEncoding.DecodeString(cv.Value)
+	case "i":
+		return strconv.ParseInt(cv.Value, 10, 64)
+	case "u":
+		return strconv.ParseUint(cv.Value, 10, 64)
+	case "f":
+		return strconv.ParseFloat(cv.Value, 64)
+	case "s":
+		return cv.Value, nil
+	case "b":
+		return strconv.ParseBool(cv.Value)
+	}
+	return nil, fmt.Errorf("unknown type %q", cv.Type)
+}
+
+func (c *context) seek(name, columns string, direction ...string) string {
+	p, err := c.Get(name)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+	// First page has no cursor.
+	if p.value == nil || p.value == "" {
+		return "1 = 1" + c.annotation(name)
+	}
+	cursor, ok := p.value.(string)
+	if !ok {
+		return c.errorOutput(fmt.Errorf("%q is not cursor string", name))
+	}
+	values, err := DecodeCursor(cursor)
+	if err != nil {
+		return c.errorOutput(&ErrInvalidCursor{Param: name, Err: err})
+	}
+	if err = safe(columns); err != nil {
+		return c.errorOutput(fmt.Errorf("%q contains prohibited character(%s)", columns, err.Error()))
+	}
+	cols := strings.Split(columns, ",")
+	for i, col := range cols {
+		cols[i] = strings.TrimSpace(col)
+	}
+	if len(cols) != len(values) {
+		return c.errorOutput(fmt.Errorf("%q has %d values, but %d columns are given", name, len(values), len(cols)))
+	}
+	op, err := seekOperator(direction)
+	if err != nil {
+		return c.errorOutput(err)
+	}
+
+	arg := func(i int) *param {
+		return newArg(fmt.Sprintf("%s%s%d", p.name, Connector, i+1), subPath(p.path, strconv.Itoa(i)), values[i])
+	}
+	if rowValueComparerOf(c.dialect).RowValueSupported() {
+		placeholders := make([]string, len(values))
+		for i := range values {
+			placeholders[i] = c.Bind(arg(i))
+		}
+		return "(" + strings.Join(cols, ", ") + ") " + op + " (" + strings.Join(placeholders, ", ") + ")" + c.annotation(name)
+	}
+
+	// Expand to `a > ? OR (a = ? AND b > ?)` when row value comparison is not supported.
+	terms := make([]string, len(cols))
+	for i := range cols {
+		conds := make([]string, i+1)
+		for j := 0; j < i; j++ {
+			conds[j] = cols[j] + " = " + c.Bind(arg(j))
+		}
+		conds[i] = cols[i] + " " + op + " " + c.Bind(arg(i))
+		if i == 0 {
+			terms[i] = conds[i]
+		} else {
+			terms[i] = "(" + strings.Join(conds, " AND ") + ")"
+		}
+	}
+	return "(" + strings.Join(terms, " OR ") + ")" + c.annotation(name)
+}
+
+func seekOperator(direction []string) (string, error) {
+	if len(direction) == 0 {
+		return ">", nil
+	}
+	switch strings.ToUpper(direction[0]) {
+	case "ASC":
+		return ">", nil
+	case "DESC":
+		return "<", nil
+	}
+	return "", fmt.Errorf("%q is invalid direction", direction[0])
+}
@@ -0,0 +1,264 @@
+package sqlt
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// LimitVar is variable of limit placeholder in DialectSpec.Pagination.
+	LimitVar = "{limit}"
+	// OffsetVar is variable of offset placeholder in DialectSpec.Pagination.
+	OffsetVar = "{offset}"
+	// ValueVar is variable of placeholder in DialectSpec.JSONCast.
+	ValueVar = "{value}"
+	// LeftVar is variable of left expression in DialectSpec.NullSafeEqual and DialectSpec.NullSafeNotEqual.
+	LeftVar = "{left}"
+	// RightVar is variable of right expression in DialectSpec.NullSafeEqual and DialectSpec.NullSafeNotEqual.
+	RightVar = "{right}"
+)
+
+// DialectSpec is declarative definition of dialect.
+// Empty optional fields are filled with standard SQL behavior.
+type DialectSpec struct {
+	// OrdinalPlaceholderPrefix is prefix of ordinal placeholder (ex: `$`).
+	// When empty, Placeholder is used.
+	OrdinalPlaceholderPrefix string `json:"ordinalPlaceholderPrefix"`
+	// Placeholder is positional placeholder (ex: `?`).
+	Placeholder string `json:"placeholder"`
+	// NamedPlaceholderPrefix is prefix of named placeholder (ex: `:`).
+	NamedPlaceholderPrefix string `json:"namedPlaceholderPrefix"`
+	// Wildcards are wildcard characters that are used with `LIKE` (ex: `%_`).
+	Wildcards string `json:"wildcards"`
+	// QuoteStart is start character for quoting identifier. Default is `"`.
+	QuoteStart string `json:"quoteStart"`
+	// QuoteEnd is end character for quoting identifier. Default is QuoteStart.
+	QuoteEnd string `json:"quoteEnd"`
+	// ConcatOperator is operator for concatenating strings. Default is `||`.
+	ConcatOperator string `json:"concatOperator"`
+	// ConcatFunction is function for concatenating strings (ex: `CONCAT`).
+	// When not empty, this is used instead of ConcatOperator.
+	ConcatFunction string `json:"concatFunction"`
+	// Pagination is pagination clause with `{limit}` and `{offset}`.
+	// Default is `LIMIT {limit} OFFSET {offset}`.
+	Pagination string `json:"pagination"`
+	// PaginationRequiresOrderBy is true if pagination clause requires `ORDER BY`.
+	PaginationRequiresOrderBy bool `json:"paginationRequiresOrderBy"`
+	// TrueLiteral is literal of true. Default is `TRUE`.
+	TrueLiteral string `json:"trueLiteral"`
+	// FalseLiteral is literal of false. Default is `FALSE`.
+	FalseLiteral string `json:"falseLiteral"`
+	// MaxBindParams is maximum count of bind parameters. 0 means unlimited.
+	MaxBindParams int `json:"maxBindParams"`
+	// MaxInListLength is maximum count of values in `IN` list. 0 means unlimited.
+	MaxInListLength int `json:"maxInListLength"`
+	// CurrentTimestamp is expression of current timestamp. Default is `CURRENT_TIMESTAMP`.
+	CurrentTimestamp string `json:"currentTimestamp"`
+	// BackslashEscape is true if backslash escapes quote in string literal (ex: MySQL).
+	BackslashEscape bool `json:"backslashEscape"`
+	// HashComment is true if `#` starts line comment (ex: MySQL).
+	HashComment bool `json:"hashComment"`
+	// DollarQuote is true if `$$ ... $$` is string literal (ex: PostgreSQL).
+	DollarQuote bool `json:"dollarQuote"`
+	// RowValueSupported is true if row value comparison (ex: `(a, b) > (1, 2)`) is supported.
+	RowValueSupported bool `json:"rowValueSupported"`
+	// JSONCast is expression for casting JSON string with `{value}` (ex: `{value}::jsonb`). Default is `{value}`.
+	JSONCast string `json:"jsonCast"`
+	// CastTypes are SQL type names for types of `cast` (ex: `{"int": "BIGINT"}`).
+	// Standard SQL type is used for missing type, and empty name means no cast.
+	CastTypes map[string]string `json:"castTypes"`
+	// NullSafeEqual is null-safe comparison with `{left}` and `{right}` (ex: `{left} IS NOT DISTINCT FROM {right}`).
+	// When empty, null-safe comparison is not supported.
+	NullSafeEqual string `json:"nullSafeEqual"`
+	// NullSafeNotEqual is negation of NullSafeEqual (ex: `{left} IS DISTINCT FROM {right}`).
+	NullSafeNotEqual string `json:"nullSafeNotEqual"`
+}
+
+// NewDialect returns dialect that is defined by given spec.
+func NewDialect(spec DialectSpec) (Dialect, error) {
+	if spec.OrdinalPlaceholderPrefix == "" && spec.Placeholder == "" {
+		return nil, errors.New("ordinal placeholder prefix or placeholder is required")
+	}
+	if spec.NamedPlaceholderPrefix == "" {
+		return nil, errors.New("named placeholder prefix is required")
+	}
+	if spec.Pagination != "" && (!strings.Contains(spec.Pagination, LimitVar) || !strings.Contains(spec.Pagination, OffsetVar)) {
+		return nil, errors.New("pagination requires " + LimitVar + " and " + OffsetVar)
+	}
+	if spec.JSONCast != "" && !strings.Contains(spec.JSONCast, ValueVar) {
+		return nil, errors.New("JSON cast requires " + ValueVar)
+	}
+	if (spec.NullSafeEqual == "") != (spec.NullSafeNotEqual == "") {
+		return nil, errors.New("null-safe equal and not equal are required together")
+	}
+	for _, ns := range []string{spec.NullSafeEqual, spec.NullSafeNotEqual} {
+		if ns != "" && (!strings.Contains(ns, LeftVar) || !strings.Contains(ns, RightVar)) {
+			return nil, errors.New("null-safe comparison requires " + LeftVar + " and " + RightVar)
+		}
+	}
+
+	if spec.QuoteStart == "" {
+		spec.QuoteStart = `"`
+	}
+	if spec.QuoteEnd == "" {
+		spec.QuoteEnd = spec.QuoteStart
+	}
+	if spec.ConcatOperator == "" {
+		spec.ConcatOperator = "||"
+	}
+	if spec.Pagination == "" {
+		spec.Pagination = "LIMIT " + LimitVar + " OFFSET " + OffsetVar
+	}
+	if spec.TrueLiteral == "" {
+		spec.TrueLiteral = "TRUE"
+	}
+	if spec.FalseLiteral == "" {
+		spec.FalseLiteral = "FALSE"
+	}
+	if spec.CurrentTimestamp == "" {
+		spec.CurrentTimestamp = "CURRENT_TIMESTAMP"
+	}
+	if spec.JSONCast == "" {
+		spec.JSONCast = ValueVar
+	}
+	return specDialect{spec: spec}, nil
+}
+
+// NewDialectFromJSON returns dialect that is defined by given JSON of DialectSpec.
+func NewDialectFromJSON(data []byte) (Dialect, error) {
+	var spec DialectSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+	return NewDialect(spec)
+}
+
+type specDialect struct {
+	spec DialectSpec
+}
+
+func (d specDialect) IsOrdinalPlaceholderSupported() bool {
+	return d.spec.OrdinalPlaceholderPrefix != ""
+}
+
+func (d specDialect) OrdinalPlaceholderPrefix() string {
+	return d.spec.OrdinalPlaceholderPrefix
+}
+
+func (d specDialect) Placeholder() string {
+	return d.spec.Placeholder
+}
+
+func (d specDialect) NamedPlaceholderPrefix() string {
+	return d.spec.NamedPlaceholderPrefix
+}
+
+func (d specDialect) WildcardRunes() []rune {
+	return []rune(d.spec.Wildcards)
+}
+
+func (d specDialect) QuoteIdentifier(name string) string {
+	return d.spec.QuoteStart + strings.Replace(name, d.spec.QuoteEnd, d.spec.QuoteEnd+d.spec.QuoteEnd, -1) + d.spec.QuoteEnd
+}
+
+func (d specDialect) IdentifierQuotes() [][2]string {
+	qs := [][2]string{{d.spec.QuoteStart, d.spec.QuoteEnd}}
+	if d.spec.QuoteStart != `"` {
+		qs = append(qs, [2]string{`"`, `"`})
+	}
+	return qs
+}
+
+func (d specDialect) BackslashEscape() bool {
+	return d.spec.BackslashEscape
+}
+
+func (d specDialect) HashComment() bool {
+	return d.spec.HashComment
+}
+
+func (d specDialect) DollarQuote() bool {
+	return d.spec.DollarQuote
+}
+
+func (d specDialect) Concat(exprs ...string) string {
+	if d.spec.ConcatFunction != "" {
+		return d.spec.ConcatFunction + "(" + strings.Join(exprs, ", ") + ")"
+	}
+	return strings.Join(exprs, " "+d.spec.ConcatOperator+" ")
+}
+
+func (d specDialect) Paginate(limit, offset string) string {
+	s := strings.Replace(d.spec.Pagination, LimitVar, limit, -1)
+	return strings.Replace(s, OffsetVar, offset, -1)
+}
+
+func (d specDialect) RequiresOrderBy() bool {
+	return d.spec.PaginationRequiresOrderBy
+}
+
+func (d specDialect) BoolLiteral(b bool) string {
+	if b {
+		return d.spec.TrueLiteral
+	}
+	return d.spec.FalseLiteral
+}
+
+func (d specDialect) StringLiteral(s string) string {
+	s = strings.Replace(s, "'", "''", -1)
+	if d.spec.BackslashEscape {
+		s = strings.Replace(s, `\`, `\\`, -1)
+	}
+	return "'" + s + "'"
+}
+
+func (d specDialect) TimeLiteral(t time.Time) string {
+	return defaultDialect{}.TimeLiteral(t)
+}
+
+func (d specDialect) BytesLiteral(b []byte) string {
+	return defaultDialect{}.BytesLiteral(b)
+}
+
+func (d specDialect) MaxBindParams() int {
+	return d.spec.MaxBindParams
+}
+
+func (d specDialect) MaxInListLength() int {
+	return d.spec.MaxInListLength
+}
+
+func (d specDialect) CurrentTimestamp() string {
+	return d.spec.CurrentTimestamp
+}
+
+func (d specDialect) RowValueSupported() bool {
+	return d.spec.RowValueSupported
+}
+
+func (d specDialect) JSONExpression(placeholder string) string {
+	return strings.Replace(d.spec.JSONCast, ValueVar, placeholder, -1)
+}
+
+func (d specDialect) CastType(typ string) string {
+	if t, ok := d.spec.CastTypes[typ]; ok {
+		return t
+	}
+	return defaultDialect{}.CastType(typ)
+}
+
+func (d specDialect) NullSafeEqual(left, right string) string {
+	if d.spec.NullSafeEqual == "" {
+		return left + " = " + right
+	}
+	return strings.Replace(strings.Replace(d.spec.NullSafeEqual, LeftVar, left, -1), RightVar, right, -1)
+}
+
+func (d specDialect) NullSafeNotEqual(left, right string) string {
+	if d.spec.NullSafeNotEqual == "" {
+		return left + " <> " + right
+	}
+	return strings.Replace(strings.Replace(d.spec.NullSafeNotEqual, LeftVar, left, -1), RightVar, right, -1)
+}
@@ -0,0 +1,199 @@
+package sqlt_test
+
+import (
+	"testing"
+
+	"github.com/pinzolo/sqlt"
+)
+
+func TestNewDialectFromJSON(t *testing.T) {
+	d, err := sqlt.NewDialectFromJSON([]byte(`{
+	"placeholder": "?",
+	"namedPlaceholderPrefix": ":",
+	"wildcards": "%_",
+	"quoteStart": "[",
+	"quoteEnd": "]",
+	"pagination": "OFFSET {offset} ROWS FETCH FIRST {limit} ROWS ONLY",
+	"trueLiteral": "1",
+	"falseLiteral": "0"
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := `
+SELECT *
+FROM users
+WHERE name LIKE /*% prefix "name" %*/''
+/*% paginate "page" "size" %*/`
+	query, args, err := sqlt.New(d).Exec(s, map[string]interface{}{
+		"name": "50%",
+		"page": 2,
+		"size": 10,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	eSQL := `
+SELECT *
+FROM users
+WHERE name LIKE ? || '%' ESCAPE '\'
+OFFSET ? ROWS FETCH FIRST ? ROWS ONLY`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 3 {
+		t.Fatalf("exec failed: values should have 3 length, but got %v", args)
+	}
+	if isInvalidString(args[0], `50\%`) {
+		t.Errorf("exec failed: 1st value should be escaped, but got %v", args)
+	}
+	if isInvalidInt(args[1], 10) {
+		t.Errorf("exec failed: 2nd value should be offset, but got %v", args)
+	}
+	if s := d.(sqlt.IdentifierQuoter).QuoteIdentifier("a]b"); s != "[a]]b]" {
+		t.Errorf("quoted identifier should be [a]]b], but got %s", s)
+	}
+	if s := d.(sqlt.BoolRenderer).BoolLiteral(true); s != "1" {
+		t.Errorf("true literal should be 1, but got %s", s)
+	}
+}
+
+func TestNewDialectDefaults(t *testing.T) {
+	d, err := sqlt.NewDialect(sqlt.DialectSpec{
+		OrdinalPlaceholderPrefix: "$",
+		NamedPlaceholderPrefix:   ":",
+		ConcatFunction:           "CONCAT",
+		RowValueSupported:        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.IsOrdinalPlaceholderSupported() {
+		t.Error("dialect should support ordinal placeholder")
+	}
+	if s := d.(sqlt.IdentifierQuoter).QuoteIdentifier("id"); s != `"id"` {
+		t.Errorf("quoted identifier should be \"id\", but got %s", s)
+	}
+	if s := d.(sqlt.Concatenator).Concat("a", "b"); s != "CONCAT(a, b)" {
+		t.Errorf("concatenation should be CONCAT(a, b), but got %s", s)
+	}
+	if s := d.(sqlt.Paginator).Paginate("l", "o"); s != "LIMIT l OFFSET o" {
+		t.Errorf("pagination should be LIMIT l OFFSET o, but got %s", s)
+	}
+	if s := d.(sqlt.CurrentTimestamper).CurrentTimestamp(); s != "CURRENT_TIMESTAMP" {
+		t.Errorf("current timestamp should be CURRENT_TIMESTAMP, but got %s", s)
+	}
+
+	cursor, err := sqlt.EncodeCursor(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, _, err := sqlt.New(d).Exec(`/*% seek "cursor" "a, b" %*/`, singleMap("cursor", cursor))
+	if err != nil {
+		t.Error(err)
+	}
+	if query != "(a, b) > ($1, $2)" {
+		t.Errorf("row value comparison should be rendered, but got %s", query)
+	}
+}
+
+func TestNewDialectError(t *testing.T) {
+	data := []struct {
+		spec sqlt.DialectSpec
+		tag  string
+	}{
+		{sqlt.DialectSpec{NamedPlaceholderPrefix: ":"}, "no placeholder"},
+		{sqlt.DialectSpec{Placeholder: "?"}, "no named placeholder prefix"},
+		{sqlt.DialectSpec{Placeholder: "?", NamedPlaceholderPrefix: ":", Pagination: "LIMIT {limit}"}, "no offset in pagination"},
+		{sqlt.DialectSpec{Placeholder: "?", NamedPlaceholderPrefix: ":", JSONCast: "CAST(? AS JSON)"}, "no value in JSON cast"},
+		{sqlt.DialectSpec{Placeholder: "?", NamedPlaceholderPrefix: ":", NullSafeEqual: "{left} <=> {right}"}, "no null-safe not equal"},
+		{sqlt.DialectSpec{Placeholder: "?", NamedPlaceholderPrefix: ":", NullSafeEqual: "{left} <=> ?", NullSafeNotEqual: "NOT ({left} <=> {right})"}, "no right in null-safe equal"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			if _, err := sqlt.NewDialect(d.spec); err == nil {
+				t.Error("should raise error on invalid spec")
+			}
+		})
+	}
+
+	if _, err := sqlt.NewDialectFromJSON([]byte(`{`)); err == nil {
+		t.Error("should raise error on invalid JSON")
+	}
+}
+
+func TestNewDialectJSONCast(t *testing.T) {
+	s := `SELECT * FROM t WHERE f = /*% json "f" %*/'{}'`
+	data := []struct {
+		spec string
+		sql  string
+		tag  string
+	}{
+		{`{"placeholder": "?", "namedPlaceholderPrefix": ":", "jsonCast": "CAST({value} AS JSON)"}`, `SELECT * FROM t WHERE f = CAST(? AS JSON)`, "cast"},
+		{`{"placeholder": "?", "namedPlaceholderPrefix": ":"}`, `SELECT * FROM t WHERE f = ?`, "default"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			dialect, err := sqlt.NewDialectFromJSON([]byte(d.spec))
+			if err != nil {
+				t.Fatal(err)
+			}
+			query, _, err := sqlt.New(dialect).Exec(s, singleMap("f", []int{1}))
+			if err != nil {
+				t.Error(err)
+			}
+			if d.sql != query {
+				t.Errorf("exec failed: expected %s, but got %s", d.sql, query)
+			}
+		})
+	}
+}
+
+func TestNewDialectCastTypes(t *testing.T) {
+	d, err := sqlt.NewDialectFromJSON([]byte(`{
+	"placeholder": "?",
+	"namedPlaceholderPrefix": ":",
+	"castTypes": {"int": "SIGNED", "bool": ""}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := `SELECT * FROM t WHERE i = /*% cast "i" "int" %*/1 AND b = /*% cast "b" "bool" %*/true AND d = /*% cast "d" "date" %*/''`
+	m := map[string]interface{}{"i": "1", "b": "true", "d": "2018-08-04"}
+	query, _, err := sqlt.New(d).Exec(s, m, sqlt.SQLCast())
+	if err != nil {
+		t.Error(err)
+	}
+	if eSQL := `SELECT * FROM t WHERE i = CAST(? AS SIGNED) AND b = ? AND d = CAST(? AS DATE)`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+}
+
+func TestNewDialectNullSafeComparison(t *testing.T) {
+	s := `SELECT * FROM t WHERE /*% equal "c" "c" %*/ AND /*% notEqual "c" "c" %*/`
+	data := []struct {
+		spec string
+		sql  string
+		tag  string
+	}{
+		{`{"placeholder": "?", "namedPlaceholderPrefix": ":", "nullSafeEqual": "{left} <=> {right}", "nullSafeNotEqual": "NOT ({left} <=> {right})"}`, `SELECT * FROM t WHERE c <=> ? AND NOT (c <=> ?)`, "null-safe"},
+		{`{"placeholder": "?", "namedPlaceholderPrefix": ":"}`, `SELECT * FROM t WHERE c = ? AND c <> ?`, "not supported"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			dialect, err := sqlt.NewDialectFromJSON([]byte(d.spec))
+			if err != nil {
+				t.Fatal(err)
+			}
+			query, _, err := sqlt.New(dialect).Exec(s, singleMap("c", 1), sqlt.NullSafeEquality())
+			if err != nil {
+				t.Error(err)
+			}
+			if d.sql != query {
+				t.Errorf("exec failed: expected %s, but got %s", d.sql, query)
+			}
+		})
+	}
+}
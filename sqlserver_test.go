@@ -237,9 +237,9 @@ OR note4 = /*% p "note" %*/''`
 	eSQL := `
 SELECT *
 FROM items
-WHERE note1 LIKE '%' || @p1 || '%' ESCAPE '\'
-OR note2 LIKE @p1 || '%' ESCAPE '\'
-OR note3 LIKE '%' || @p1 ESCAPE '\'
+WHERE note1 LIKE '%' + @p1 + '%' ESCAPE '\'
+OR note2 LIKE @p1 + '%' ESCAPE '\'
+OR note3 LIKE '%' + @p1 ESCAPE '\'
 OR note4 = @p2`
 	if eSQL != query {
 		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
@@ -273,9 +273,9 @@ OR note4 = /*% p "note" %*/''`
 	eSQL := `
 SELECT *
 FROM items
-WHERE note1 LIKE '%' || @note__esc || '%' ESCAPE '\'
-OR note2 LIKE @note__esc || '%' ESCAPE '\'
-OR note3 LIKE '%' || @note__esc ESCAPE '\'
+WHERE note1 LIKE '%' + @note__esc + '%' ESCAPE '\'
+OR note2 LIKE @note__esc + '%' ESCAPE '\'
+OR note3 LIKE '%' + @note__esc ESCAPE '\'
 OR note4 = @note`
 	if eSQL != query {
 		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
@@ -309,9 +309,9 @@ OR note4 = /*% p "note" %*/''`
 	eSQL := `
 SELECT *
 FROM items
-WHERE note1 LIKE '%' || @p1 || '%' ESCAPE '\'
-OR note2 LIKE @p1 || '%' ESCAPE '\'
-OR note3 LIKE '%' || @p1 ESCAPE '\'
+WHERE note1 LIKE '%' + @p1 + '%' ESCAPE '\'
+OR note2 LIKE @p1 + '%' ESCAPE '\'
+OR note3 LIKE '%' + @p1 ESCAPE '\'
 OR note4 = @p1`
 	if eSQL != query {
 		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
@@ -342,9 +342,9 @@ OR note4 = /*% p "note" %*/''`
 	eSQL := `
 SELECT *
 FROM items
-WHERE note1 LIKE '%' || @note || '%' ESCAPE '\'
-OR note2 LIKE @note || '%' ESCAPE '\'
-OR note3 LIKE '%' || @note ESCAPE '\'
+WHERE note1 LIKE '%' + @note + '%' ESCAPE '\'
+OR note2 LIKE @note + '%' ESCAPE '\'
+OR note3 LIKE '%' + @note ESCAPE '\'
 OR note4 = @note`
 	if eSQL != query {
 		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
@@ -356,3 +356,110 @@ OR note4 = @note`
 		t.Errorf("exec failed: 1st value %v is invalid", args[0])
 	}
 }
+
+func TestSQLServerPaginate(t *testing.T) {
+	s := `SELECT * FROM users ORDER BY id /*% paginate "page" "size" %*/`
+	query, args, err := sqlt.New(sqlt.SQLServer).Exec(s, map[string]interface{}{
+		"page": 2,
+		"size": 20,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	eSQL := `SELECT * FROM users ORDER BY id OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 2 {
+		t.Errorf("exec failed: values should have 2 length, but got %v", args)
+	}
+	if isInvalidInt(args[0], 20) {
+		t.Errorf("exec failed: 1st value should be 20, but got %v", args)
+	}
+	if isInvalidInt(args[1], 20) {
+		t.Errorf("exec failed: 2nd value should be 20, but got %v", args)
+	}
+}
+
+func TestSQLServerPaginateWithoutOrderBy(t *testing.T) {
+	s := `SELECT * FROM users /*% paginate "page" "size" %*/`
+	_, _, err := sqlt.New(sqlt.SQLServer).Exec(s, map[string]interface{}{
+		"page": 2,
+		"size": 20,
+	})
+	if err == nil {
+		t.Error("should raise error when ORDER BY is missing")
+	}
+}
+
+func TestSQLServerSeek(t *testing.T) {
+	s := `SELECT * FROM users u WHERE /*% seek "cursor" "u.created_at, u.id" "desc" %*/`
+	cursor, err := sqlt.EncodeCursor("2018-08-04", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, args, err := sqlt.New(sqlt.SQLServer).Exec(s, singleMap("cursor", cursor))
+	if err != nil {
+		t.Error(err)
+	}
+
+	eSQL := `SELECT * FROM users u WHERE (u.created_at < @p1 OR (u.created_at = @p1 AND u.id < @p2))`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 2 {
+		t.Errorf("exec failed: values should have 2 length, but got %v", args)
+	}
+}
+
+func TestSQLServerTooManyParams(t *testing.T) {
+	s := `SELECT * FROM users WHERE name = /*% p "name" %*/'' AND id IN /*% in "ids" %*/(1)`
+	ids := make([]int, 2100)
+	for i := range ids {
+		ids[i] = i
+	}
+	_, _, err := sqlt.New(sqlt.SQLServer).Exec(s, map[string]interface{}{
+		"name": "Alex",
+		"ids":  ids,
+	})
+	if err == nil {
+		t.Fatal("should raise error when bind parameters exceed maximum count")
+	}
+	e, ok := err.(*sqlt.ErrTooManyParams)
+	if !ok {
+		t.Fatalf("error should be ErrTooManyParams, but got %T", err)
+	}
+	if e.Count != 2101 || e.Max != 2100 {
+		t.Errorf("error should have count 2101 and max 2100, but got %d and %d", e.Count, e.Max)
+	}
+	if len(e.Params) != 1 || e.Params[0] != "ids" {
+		t.Errorf("error should name ids, but got %v", e.Params)
+	}
+}
+
+func TestSQLServerListBinding(t *testing.T) {
+	s := `SELECT * FROM users WHERE name = /*% p "name" %*/'' AND id IN /*% in "ids" %*/(1)`
+	ids := make([]int, 2100)
+	for i := range ids {
+		ids[i] = i
+	}
+	query, args, err := sqlt.New(sqlt.SQLServer).WithOptions(sqlt.ListBinding()).Exec(s, map[string]interface{}{
+		"name": "Alex",
+		"ids":  ids,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eSQL := `SELECT * FROM users WHERE name = @p1 AND id IN (SELECT value FROM OPENJSON(@p2))`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 2 {
+		t.Fatalf("exec failed: values should have 2 length, but got %v", args)
+	}
+	if s, ok := args[1].(string); !ok || s[:7] != "[0,1,2," {
+		t.Errorf("exec failed: 2nd value should be JSON array, but got %v", args[1])
+	}
+}
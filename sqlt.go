@@ -3,7 +3,9 @@ package sqlt
 import (
 	"bytes"
 	"database/sql"
+	"errors"
 	"regexp"
+	"strings"
 	"text/template"
 	"time"
 )
@@ -25,17 +27,46 @@ var (
 
 // Config is configuration for executing template.
 type config struct {
-	timeFunc   func() time.Time
-	annotative bool
+	timeFunc    func() time.Time
+	annotative  bool
+	rowNum      bool
+	profile     DriverProfile
+	listBinding bool
+	verify      bool
+	trustedOnly bool
+	allowList   []string
+	sanitizer   Sanitizer
+	strictJSON  bool
+	sqlCast     bool
+	nullSafe    bool
 }
 
 func (conf *config) clone() *config {
 	return &config{
-		timeFunc:   conf.timeFunc,
-		annotative: conf.annotative,
+		timeFunc:    conf.timeFunc,
+		annotative:  conf.annotative,
+		rowNum:      conf.rowNum,
+		profile:     conf.profile,
+		listBinding: conf.listBinding,
+		verify:      conf.verify,
+		trustedOnly: conf.trustedOnly,
+		allowList:   conf.allowList,
+		sanitizer:   conf.sanitizer,
+		strictJSON:  conf.strictJSON,
+		sqlCast:     conf.sqlCast,
+		nullSafe:    conf.nullSafe,
 	}
 }
 
+func (conf *config) allowed(s string) bool {
+	for _, a := range conf.allowList {
+		if a == s {
+			return true
+		}
+	}
+	return false
+}
+
 func (conf *config) apply(opts []Option) *config {
 	if len(opts) > 0 {
 		cf := conf.clone()
@@ -47,11 +78,17 @@ func (conf *config) apply(opts []Option) *config {
 	return conf
 }
 
+// Raw is trusted SQL fragment.
+// `out` embeds Raw value without checking prohibited characters.
+type Raw string
+
 // SQLTemplate is template struct.
 type SQLTemplate struct {
 	dialect Dialect
 	// customFuncs are custom functions that are used in template.
 	customFuncs map[string]interface{}
+	// binderFuncs are custom functions that can bind parameters.
+	binderFuncs map[string]BinderFunc
 	config      *config
 }
 
@@ -60,6 +97,7 @@ func New(dialect Dialect) *SQLTemplate {
 	return &SQLTemplate{
 		dialect:     dialect,
 		customFuncs: make(map[string]interface{}),
+		binderFuncs: make(map[string]BinderFunc),
 		config:      &config{},
 	}
 }
@@ -78,6 +116,12 @@ func (st *SQLTemplate) AddFuncs(funcs map[string]interface{}) *SQLTemplate {
 	return st
 }
 
+// AddBinderFunc add custom template func that can bind parameters via Binder.
+func (st *SQLTemplate) AddBinderFunc(name string, fn BinderFunc) *SQLTemplate {
+	st.binderFuncs[name] = fn
+	return st
+}
+
 // WithOptions apply given options.
 func (st *SQLTemplate) WithOptions(opts ...Option) *SQLTemplate {
 	for _, opt := range opts {
@@ -89,45 +133,105 @@ func (st *SQLTemplate) WithOptions(opts ...Option) *SQLTemplate {
 // Exec executes given template with given map parameters.
 // This function replaces to normal placeholder.
 func (st *SQLTemplate) Exec(text string, m map[string]interface{}, opts ...Option) (string, []interface{}, error) {
-	conf := st.config.apply(opts)
-	c := newContext(false, st.dialect, m, conf)
-	s, err := st.exec(c, text, m)
-	if err != nil {
+	r, err := st.render(false, false, text, m, opts)
+	if r == nil {
 		return "", nil, err
 	}
-	if c.err != nil && !c.config.annotative {
-		return "", nil, c.err
-	}
-	return s, c.Args(), c.err
+	return r.SQL, r.Args, err
 }
 
 // ExecNamed executes given template with given map parameters.
 // This function replaces to named placeholder.
 func (st *SQLTemplate) ExecNamed(text string, m map[string]interface{}, opts ...Option) (string, []sql.NamedArg, error) {
+	r, err := st.render(true, false, text, m, opts)
+	if r == nil {
+		return "", nil, err
+	}
+	return r.SQL, r.NamedArgs, err
+}
+
+// ExecCount executes given template with given map parameters, and wraps it for counting rows.
+// Pagination and order clauses rendered by `paginate`, `limit` and `orderBy` are dropped.
+// This function replaces to normal placeholder.
+func (st *SQLTemplate) ExecCount(text string, m map[string]interface{}, opts ...Option) (string, []interface{}, error) {
+	r, err := st.render(false, true, text, m, opts)
+	if r == nil {
+		return "", nil, err
+	}
+	return r.SQL, r.Args, err
+}
+
+// ExecNamedCount executes given template with given map parameters, and wraps it for counting rows.
+// Pagination and order clauses rendered by `paginate`, `limit` and `orderBy` are dropped.
+// This function replaces to named placeholder.
+func (st *SQLTemplate) ExecNamedCount(text string, m map[string]interface{}, opts ...Option) (string, []sql.NamedArg, error) {
+	r, err := st.render(true, true, text, m, opts)
+	if r == nil {
+		return "", nil, err
+	}
+	return r.SQL, r.NamedArgs, err
+}
+
+// Render executes given template with given map parameters, and returns detailed result.
+// This function replaces to normal placeholder.
+func (st *SQLTemplate) Render(text string, m map[string]interface{}, opts ...Option) (*Result, error) {
+	return st.render(false, false, text, m, opts)
+}
+
+// RenderNamed executes given template with given map parameters, and returns detailed result.
+// This function replaces to named placeholder.
+func (st *SQLTemplate) RenderNamed(text string, m map[string]interface{}, opts ...Option) (*Result, error) {
+	return st.render(true, false, text, m, opts)
+}
+
+func (st *SQLTemplate) render(named bool, counting bool, text string, m map[string]interface{}, opts []Option) (*Result, error) {
 	conf := st.config.apply(opts)
-	c := newContext(true, st.dialect, m, conf)
+	if named && conf.profile != nil && conf.profile.NamedPlaceholderPrefix() == "" {
+		return nil, errors.New("driver profile does not support named placeholder")
+	}
+	c := newContext(named, st.dialect, m, conf)
+	c.counting = counting
 	s, err := st.exec(c, text, m)
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 	if c.err != nil && !c.config.annotative {
-		return "", nil, c.err
+		return nil, c.err
+	}
+	if err = c.checkParamLimit(); err != nil {
+		return nil, err
+	}
+	if counting {
+		s = countQuery(s)
 	}
-	return s, c.NamedArgs(), c.err
+	if c.config.verify {
+		if err = c.verify(s); err != nil {
+			return nil, err
+		}
+	}
+	return c.result(s), c.err
 }
 
 func (st *SQLTemplate) exec(c *context, text string, m map[string]interface{}) (string, error) {
-	t, err := template.New("").Funcs(c.funcMap(st.customFuncs)).Delims(LeftDelim, RightDelim).Parse(dropSample(text))
+	t, err := template.New("").Funcs(c.funcMap(st.customFuncs, st.binderFuncs)).Delims(LeftDelim, RightDelim).Parse(dropSample(text))
 	if err != nil {
 		return "", err
 	}
 	buf := &bytes.Buffer{}
+	c.buf = buf
 	if err = t.Execute(buf, nil); err != nil {
 		return "", err
 	}
+	if c.pagination != nil {
+		return c.wrapRowNum(buf.String()), nil
+	}
 	return buf.String(), nil
 }
 
+func countQuery(s string) string {
+	return "SELECT COUNT(*) FROM (" + strings.TrimSpace(s) + ") t"
+}
+
 func dropSample(text string) string {
 	s := strRegex.ReplaceAllString(text, RightDelim)
 	s = inRegex.ReplaceAllString(s, RightDelim)
@@ -2,7 +2,12 @@ package sqlt_test
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -1010,7 +1015,7 @@ func TestAnnotationError(t *testing.T) {
 		tag  string
 	}{
 		{`/*% p "bar" %*/`, "test", `/*# error: "bar" is unknown param */`, "name not found"},
-		{`/*% out "foo" %*/`, "test;", `/*# error: "foo" contains prohibited character(semi colon) */`, "prohibited character"},
+		{`/*% out "foo" %*/`, "test;", `/*# error: "foo" is rejected by legacy policy: prohibited character(semi colon) */`, "prohibited character"},
 		{`/*% get "foo" %*/`, "test;", "<no value>", "get does not annotate error"},
 	}
 	for _, d := range data {
@@ -1034,7 +1039,7 @@ func TestAnnotationErrorNamed(t *testing.T) {
 		tag  string
 	}{
 		{`/*% p "bar" %*/`, "test", `/*# error: "bar" is unknown param */`, "name not found"},
-		{`/*% out "foo" %*/`, "test;", `/*# error: "foo" contains prohibited character(semi colon) */`, "prohibited character"},
+		{`/*% out "foo" %*/`, "test;", `/*# error: "foo" is rejected by legacy policy: prohibited character(semi colon) */`, "prohibited character"},
 		{`/*% get "foo" %*/`, "test;", "<no value>", "get does not annotate error"},
 	}
 	for _, d := range data {
@@ -1432,3 +1437,1527 @@ func isInvalidStringArg(arg sql.NamedArg, name string, s string) bool {
 func isBetweenTime(bt, tm, et time.Time) bool {
 	return bt.Unix() <= tm.Unix() && tm.Unix() <= et.Unix()
 }
+
+func TestExecCount(t *testing.T) {
+	s := `
+SELECT *
+FROM users
+WHERE name LIKE /*% prefix "name" %*/''
+/*% orderBy "order" %*/
+/*% paginate "page" "size" %*/`
+	query, args, err := sqlt.New(sqlt.Postgres).ExecCount(s, map[string]interface{}{
+		"name":  "Alex",
+		"order": "id DESC",
+		"page":  2,
+		"size":  20,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	eSQL := `SELECT COUNT(*) FROM (SELECT *
+FROM users
+WHERE name LIKE $1 || '%' ESCAPE '\') t`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 1 {
+		t.Errorf("exec failed: values should have 1 length, but got %v", args)
+	}
+	if isInvalidString(args[0], "Alex") {
+		t.Errorf("exec failed: values should have 'Alex', but got %v", args)
+	}
+}
+
+func TestExecNamedCount(t *testing.T) {
+	s := `SELECT * FROM users WHERE age > /*% p "age" %*/20 /*% orderBy "order" %*/ /*% limit "limit" "offset" %*/`
+	query, args, err := sqlt.New(sqlt.SQLServer).ExecNamedCount(s, map[string]interface{}{
+		"age":    30,
+		"order":  "id DESC",
+		"limit":  10,
+		"offset": 0,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	eSQL := `SELECT COUNT(*) FROM (SELECT * FROM users WHERE age > @age) t`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 1 {
+		t.Errorf("exec failed: values should have 1 length, but got %v", args)
+	}
+	if isInvalidIntArg(args[0], "age", 30) {
+		t.Errorf("exec failed: values should have age = 30, but got %v", args)
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	s := `SELECT * FROM users /*% orderBy "order" %*/`
+	query, _, err := sqlt.New(sqlt.Postgres).Exec(s, singleMap("order", "name DESC"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	eSQL := `SELECT * FROM users ORDER BY name DESC`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+
+	if _, _, err = sqlt.New(sqlt.Postgres).Exec(s, singleMap("order", "name; DROP TABLE users")); err == nil {
+		t.Error("should raise error on value contains prohibited character")
+	}
+}
+
+func TestCursor(t *testing.T) {
+	tm := time.Date(2018, 8, 4, 12, 34, 56, 789, time.UTC)
+	cursor, err := sqlt.EncodeCursor(tm, 123, "Alex", nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := sqlt.DecodeCursor(cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 5 {
+		t.Fatalf("decode failed: values should have 5 length, but got %v", values)
+	}
+	if v, ok := values[0].(time.Time); !ok || !v.Equal(tm) {
+		t.Errorf("decode failed: 1st value should be %v, but got %v", tm, values[0])
+	}
+	if v, ok := values[1].(int64); !ok || v != 123 {
+		t.Errorf("decode failed: 2nd value should be 123, but got %v", values[1])
+	}
+	if isInvalidString(values[2], "Alex") {
+		t.Errorf("decode failed: 3rd value should be 'Alex', but got %v", values[2])
+	}
+	if values[3] != nil {
+		t.Errorf("decode failed: 4th value should be nil, but got %v", values[3])
+	}
+	if v, ok := values[4].(bool); !ok || !v {
+		t.Errorf("decode failed: 5th value should be true, but got %v", values[4])
+	}
+
+	if _, err = sqlt.DecodeCursor("invalid cursor"); err == nil {
+		t.Error("should raise error on invalid cursor")
+	}
+}
+
+func TestSeek(t *testing.T) {
+	s := `SELECT * FROM users u WHERE /*% seek "cursor" "u.created_at, u.id" %*/ ORDER BY u.created_at, u.id`
+	tm := time.Date(2018, 8, 4, 12, 34, 56, 0, time.UTC)
+	cursor, err := sqlt.EncodeCursor(tm, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, args, err := sqlt.New(sqlt.Postgres).Exec(s, singleMap("cursor", cursor))
+	if err != nil {
+		t.Error(err)
+	}
+
+	eSQL := `SELECT * FROM users u WHERE (u.created_at, u.id) > ($1, $2) ORDER BY u.created_at, u.id`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 2 {
+		t.Errorf("exec failed: values should have 2 length, but got %v", args)
+	}
+	if v, ok := args[0].(time.Time); !ok || !v.Equal(tm) {
+		t.Errorf("exec failed: 1st value should be %v, but got %v", tm, args[0])
+	}
+}
+
+func TestSeekWithoutCursor(t *testing.T) {
+	s := `SELECT * FROM users u WHERE /*% seek "cursor" "u.created_at, u.id" "desc" %*/ ORDER BY u.created_at DESC, u.id DESC`
+	query, args, err := sqlt.New(sqlt.Postgres).Exec(s, singleMap("cursor", ""))
+	if err != nil {
+		t.Error(err)
+	}
+
+	eSQL := `SELECT * FROM users u WHERE 1 = 1 ORDER BY u.created_at DESC, u.id DESC`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 0 {
+		t.Errorf("exec failed: values should have 0 length, but got %v", args)
+	}
+}
+
+func TestSeekError(t *testing.T) {
+	cursor, err := sqlt.EncodeCursor(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []struct {
+		tmpl   string
+		cursor interface{}
+		tag    string
+	}{
+		{`/*% seek "cursor" "u.created_at, u.id" %*/`, cursor, "column count mismatch"},
+		{`/*% seek "cursor" "u.id" "up" %*/`, cursor, "invalid direction"},
+		{`/*% seek "cursor" "u.id;" %*/`, cursor, "prohibited character"},
+		{`/*% seek "cursor" "u.id" %*/`, "!!!", "invalid cursor"},
+		{`/*% seek "cursor" "u.id" %*/`, 10, "not string"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			_, _, err := sqlt.New(sqlt.Postgres).Exec(d.tmpl, singleMap("cursor", d.cursor))
+			if err == nil {
+				t.Error("should raise error")
+			}
+		})
+	}
+}
+
+func TestSeekInvalidCursorAnnotation(t *testing.T) {
+	cursor := base64.RawURLEncoding.EncodeToString([]byte(`[{"t":"i","v":"*/ OR 1=1 --"}]`))
+	query, _, err := sqlt.New(sqlt.Postgres).WithOptions(sqlt.Annotation()).Exec(`SELECT * FROM users u WHERE /*% seek "cursor" "u.id" %*/`, singleMap("cursor", cursor))
+	e, ok := err.(*sqlt.ErrInvalidCursor)
+	if !ok {
+		t.Fatalf("should raise ErrInvalidCursor, but got %v", err)
+	}
+	if e.Err == nil {
+		t.Error("cause should be kept")
+	}
+	if eSQL := `SELECT * FROM users u WHERE /*# error: "cursor" is invalid cursor */`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+}
+
+func TestRenderBindingsWithoutParameter(t *testing.T) {
+	s := `INSERT INTO logs (a, b, c) VALUES (/*% time %*/ , /*% now %*/ , /*% pv 1 %*/ )`
+	r, err := sqlt.New(sqlt.Postgres).Render(s, map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Bindings) != 3 {
+		t.Fatalf("render failed: bindings should have 3 length, but got %v", r.Bindings)
+	}
+	for _, b := range r.Bindings {
+		if b.Path != "" {
+			t.Errorf("render failed: path of %s should be empty, but got %s", b.Name, b.Path)
+		}
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	tm := time.Date(2018, 8, 4, 12, 34, 56, 0, time.UTC)
+	data := []struct {
+		dialect sqlt.Dialect
+		query   string
+		args    []interface{}
+		want    string
+		tag     string
+	}{
+		{
+			sqlt.Postgres,
+			`SELECT * FROM users WHERE name = $1 AND note = '$2' AND age > $2::int AND deleted = $3 AND created_at < $4 AND id = $2`,
+			[]interface{}{"O'Reilly", 20, false, tm},
+			`SELECT * FROM users WHERE name = 'O''Reilly' AND note = '$2' AND age > 20::int AND deleted = FALSE AND created_at < TIMESTAMP WITH TIME ZONE '2018-08-04 12:34:56+00:00' AND id = 20`,
+			"postgres",
+		},
+		{
+			sqlt.MySQL,
+			`SELECT * FROM users WHERE name = ? AND note = ? AND deleted = ? /* ? */`,
+			[]interface{}{`a\b`, nil, true},
+			`SELECT * FROM users WHERE name = 'a\\b' AND note = NULL AND deleted = TRUE /* ? */`,
+			"mysql",
+		},
+		{
+			sqlt.Oracle,
+			`SELECT * FROM users WHERE name = :1 AND deleted = :2 AND created_at < :3`,
+			[]interface{}{"Alex", true, tm},
+			`SELECT * FROM users WHERE name = 'Alex' AND deleted = 1 AND created_at < TIMESTAMP '2018-08-04 12:34:56 +00:00'`,
+			"oracle",
+		},
+		{
+			sqlt.SQLServer,
+			`SELECT * FROM users WHERE name = @p1 AND created_at < @p2`,
+			[]interface{}{"日本", tm},
+			`SELECT * FROM users WHERE name = N'日本' AND created_at < CAST('2018-08-04 12:34:56 +00:00' AS DATETIMEOFFSET)`,
+			"sqlserver",
+		},
+		{
+			sqlt.SQLServer,
+			`SELECT * FROM users WHERE name = @name AND age > @age AND id = @unknown`,
+			[]interface{}{sql.Named("name", "Alex"), sql.Named("age", 20)},
+			`SELECT * FROM users WHERE name = N'Alex' AND age > 20 AND id = @unknown`,
+			"named",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			if s := sqlt.Interpolate(d.dialect, d.query, d.args); s != d.want {
+				t.Errorf("interpolate failed: expected %s, but got %s", d.want, s)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	s := `
+SELECT *
+FROM users
+WHERE id IN /*% in "ids" %*/(1, 2)
+AND name = /*% p "user.Value" %*/'John Doe'
+/*%- if get "onlyMale" %*/
+AND sex = 'MALE'
+/*%- end %*/`
+	r, err := sqlt.New(sqlt.Postgres).Render(s, map[string]interface{}{
+		"ids":      []int{1, 2, 3},
+		"user":     Foo{"Alex"},
+		"onlyMale": false,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eSQL := `
+SELECT *
+FROM users
+WHERE id IN ($1, $2, $3)
+AND name = $4`
+	if eSQL != r.SQL {
+		t.Errorf("render failed: expected %s, but got %s", eSQL, r.SQL)
+	}
+	if len(r.Args) != 4 || len(r.NamedArgs) != 4 || len(r.Bindings) != 4 {
+		t.Fatalf("render failed: args, named args and bindings should have 4 length, but got %v, %v, %v", r.Args, r.NamedArgs, r.Bindings)
+	}
+	eBindings := []sqlt.Binding{
+		{Placeholder: "$1", Name: "ids__1", Path: "ids.0"},
+		{Placeholder: "$2", Name: "ids__2", Path: "ids.1"},
+		{Placeholder: "$3", Name: "ids__3", Path: "ids.2"},
+		{Placeholder: "$4", Name: "user__Value", Path: "user.Value"},
+	}
+	for i, b := range eBindings {
+		if r.Bindings[i] != b {
+			t.Errorf("render failed: expected binding %v, but got %v", b, r.Bindings[i])
+		}
+	}
+	eParams := []string{"ids", "user.Value", "onlyMale"}
+	if fmt.Sprint(r.Params) != fmt.Sprint(eParams) {
+		t.Errorf("render failed: expected params %v, but got %v", eParams, r.Params)
+	}
+	if len(r.Warnings) != 0 {
+		t.Errorf("render failed: warnings should be empty, but got %v", r.Warnings)
+	}
+
+	eStr := `
+SELECT *
+FROM users
+WHERE id IN (1, 2, 3)
+AND name = 'Alex'`
+	if eStr != r.String() {
+		t.Errorf("render failed: expected %s, but got %s", eStr, r.String())
+	}
+}
+
+func TestRenderNamedWithWarnings(t *testing.T) {
+	s := `SELECT * FROM users WHERE name = /*% p "name" %*/'' AND age = /*% p "age" %*/0 ORDER BY /*% out "order" %*/id`
+	r, err := sqlt.New(sqlt.SQLServer).WithOptions(sqlt.Annotation()).RenderNamed(s, map[string]interface{}{
+		"name":  "Alex",
+		"order": "id;",
+	})
+	if err == nil {
+		t.Error("should raise error")
+	}
+	if r == nil {
+		t.Fatal("render should return result in annotative mode")
+	}
+	if len(r.Warnings) != 2 {
+		t.Errorf("render failed: warnings should have 2 length, but got %v", r.Warnings)
+	}
+
+	eStr := `SELECT * FROM users WHERE name = N'Alex'/*# name */ AND age = /*# error: "age" is unknown param */ ORDER BY /*# error: "order" is rejected by legacy policy: prohibited character(semi colon) */`
+	if eStr != r.String() {
+		t.Errorf("render failed: expected %s, but got %s", eStr, r.String())
+	}
+}
+
+func TestProfile(t *testing.T) {
+	s := `SELECT * FROM users WHERE id IN /*% in "ids" %*/(1) AND name = /*% p "name" %*/''`
+	m := map[string]interface{}{
+		"ids":  []int{1, 2},
+		"name": "Alex",
+	}
+	data := []struct {
+		dialect sqlt.Dialect
+		profile sqlt.DriverProfile
+		want    string
+		tag     string
+	}{
+		{sqlt.Postgres, sqlt.PgxProfile, `SELECT * FROM users WHERE id IN (@ids__1, @ids__2) AND name = @name`, "pgx"},
+		{sqlt.Postgres, sqlt.SqlxProfile, `SELECT * FROM users WHERE id IN (:ids__1, :ids__2) AND name = :name`, "sqlx"},
+		{sqlt.SQLServer, sqlt.MssqlProfile, `SELECT * FROM users WHERE id IN (@ids__1, @ids__2) AND name = @name`, "mssql"},
+		{sqlt.Oracle, sqlt.GodrorProfile, `SELECT * FROM users WHERE id IN (:ids__1, :ids__2) AND name = :name`, "godror"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			query, args, err := sqlt.New(d.dialect).WithOptions(sqlt.Profile(d.profile)).ExecNamed(s, m)
+			if err != nil {
+				t.Error(err)
+			}
+			if d.want != query {
+				t.Errorf("exec failed: expected %s, but got %s", d.want, query)
+			}
+			if len(args) != 3 {
+				t.Errorf("exec failed: values should have 3 length, but got %v", args)
+			}
+		})
+	}
+}
+
+func TestProfileWithoutNamedPlaceholder(t *testing.T) {
+	s := `SELECT * FROM users WHERE id = /*% p "id" %*/1`
+	st := sqlt.New(sqlt.MySQL).WithOptions(sqlt.Profile(sqlt.MysqlProfile))
+	if _, _, err := st.ExecNamed(s, singleMap("id", 1)); err == nil {
+		t.Error("should raise error when driver does not support named placeholder")
+	}
+
+	query, _, err := st.Exec(s, singleMap("id", 1))
+	if err != nil {
+		t.Error(err)
+	}
+	if eSQL := `SELECT * FROM users WHERE id = ?`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+
+	st = sqlt.New(sqlt.Postgres).WithOptions(sqlt.Profile(sqlt.PqProfile))
+	if _, _, err := st.ExecNamed(s, singleMap("id", 1)); err == nil {
+		t.Error("should raise error when driver does not support named placeholder")
+	}
+}
+
+func TestProfileResultString(t *testing.T) {
+	s := `SELECT * FROM users WHERE id = /*% p "id" %*/1`
+	r, err := sqlt.New(sqlt.Postgres).RenderNamed(s, singleMap("id", 1), sqlt.Profile(sqlt.PgxProfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eSQL := `SELECT * FROM users WHERE id = 1`; eSQL != r.String() {
+		t.Errorf("render failed: expected %s, but got %s", eSQL, r.String())
+	}
+}
+
+func TestOutRaw(t *testing.T) {
+	s := `SELECT * FROM users WHERE id IN ( /*% out "sub" %*/ ) AND name = /*% p "name" %*/''`
+	query, _, err := sqlt.New(sqlt.Postgres).WithOptions(sqlt.Annotation()).Exec(s, map[string]interface{}{
+		"sub":  sqlt.Raw(`SELECT user_id FROM members WHERE role = 'admin'`),
+		"name": "Alex",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eSQL := `SELECT * FROM users WHERE id IN ( SELECT user_id FROM members WHERE role = 'admin'/*# raw: sub */ ) AND name = $1/*# name */`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+
+	if _, _, err = sqlt.New(sqlt.Postgres).Exec(s, map[string]interface{}{
+		"sub":  `SELECT user_id FROM members WHERE role = 'admin'`,
+		"name": "Alex",
+	}); err == nil {
+		t.Error("should raise error on plain string contains prohibited character")
+	}
+}
+
+func TestTrustedOnly(t *testing.T) {
+	s := `SELECT * FROM users ORDER BY /*% out "col" %*/id`
+	data := []struct {
+		col   interface{}
+		valid bool
+		tag   string
+	}{
+		{"name", true, "allow-listed"},
+		{sqlt.Raw("name DESC"), true, "raw"},
+		{"email", false, "not allow-listed"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			_, _, err := sqlt.New(sqlt.Postgres).Exec(s, singleMap("col", d.col), sqlt.TrustedOnly("id", "name"))
+			if d.valid {
+				if err != nil {
+					t.Error(err)
+				}
+			} else {
+				if err == nil {
+					t.Error("should raise error on untrusted value")
+				}
+			}
+		})
+	}
+}
+
+func TestSanitizer(t *testing.T) {
+	data := []struct {
+		sanitizer sqlt.Sanitizer
+		val       interface{}
+		valid     bool
+		policy    string
+		tag       string
+	}{
+		{sqlt.LegacySanitizer, "name DESC", true, "legacy", "legacy valid"},
+		{sqlt.LegacySanitizer, "name;", false, "legacy", "legacy invalid"},
+		{sqlt.IdentifierSanitizer, "u.name", true, "identifier", "identifier valid"},
+		{sqlt.IdentifierSanitizer, "name DESC", false, "identifier", "identifier invalid"},
+		{sqlt.IdentifierSanitizer, "1 OR 1=1", false, "identifier", "identifier injection"},
+		{sqlt.NumericSanitizer, 10, true, "numeric", "numeric valid"},
+		{sqlt.NumericSanitizer, "1.5", true, "numeric", "numeric decimal"},
+		{sqlt.NumericSanitizer, "1 UNION SELECT", false, "numeric", "numeric invalid"},
+		{sqlt.KeywordSanitizer("name", "email", "asc", "desc"), "name DESC, email asc", true, "keyword", "keyword valid"},
+		{sqlt.KeywordSanitizer("name", "asc", "desc"), "name DESC UNION", false, "keyword", "keyword invalid"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			for _, tmpl := range []string{`/*% out "val" %*/`, `/*% get "val" %*/`} {
+				_, _, err := sqlt.New(sqlt.MySQL).Exec(tmpl, singleMap("val", d.val), sqlt.Sanitize(d.sanitizer))
+				if d.valid {
+					if err != nil {
+						t.Error(err)
+					}
+					continue
+				}
+				e, ok := err.(*sqlt.ErrRejected)
+				if !ok {
+					t.Errorf("should raise ErrRejected, but got %v", err)
+					continue
+				}
+				if e.Policy != d.policy || e.Param != "val" {
+					t.Errorf("error should have policy %q and param %q, but got %q and %q", d.policy, "val", e.Policy, e.Param)
+				}
+			}
+		})
+	}
+}
+
+func TestSanitizerAnnotation(t *testing.T) {
+	s := `SELECT * FROM users ORDER BY /*% out "sort" %*/id`
+	query, _, err := sqlt.New(sqlt.Postgres).WithOptions(sqlt.Annotation()).Exec(s, singleMap("sort", "id*/UNION/**/SELECT"), sqlt.Sanitize(sqlt.KeywordSanitizer("id")))
+	if _, ok := err.(*sqlt.ErrRejected); !ok {
+		t.Errorf("should raise ErrRejected, but got %v", err)
+	}
+	if eSQL := `SELECT * FROM users ORDER BY /*# error: "sort" is rejected by keyword policy: not allowed keyword */`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+}
+
+func TestAnnotationBreaksEndOfComment(t *testing.T) {
+	query, _, err := sqlt.New(sqlt.Postgres).WithOptions(sqlt.Annotation()).Exec(`SELECT * FROM users WHERE id = /*% p "id*/x" %*/1`, map[string]interface{}{})
+	if err == nil {
+		t.Error("should raise error")
+	}
+	if eSQL := `SELECT * FROM users WHERE id = /*# error: "id* /x" is unknown param */`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+}
+
+func TestNumFunc(t *testing.T) {
+	s := `SELECT * FROM users LIMIT /*% num "limit" %*/10`
+	data := []struct {
+		val   interface{}
+		want  string
+		valid bool
+		tag   string
+	}{
+		{20, "20", true, "int"},
+		{uint8(5), "5", true, "uint"},
+		{1.5, "1.5", true, "float"},
+		{float32(0.1), "0.1", true, "float32"},
+		{"30", "30", true, "integer string"},
+		{"-0.25", "-0.25", true, "decimal string"},
+		{"10 OR 1=1", "", false, "injection"},
+		{"1e5", "", false, "exponent"},
+		{true, "", false, "bool"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			query, _, err := sqlt.New(sqlt.Postgres).Exec(s, singleMap("limit", d.val))
+			if !d.valid {
+				if _, ok := err.(*sqlt.ErrRejected); !ok {
+					t.Errorf("should raise ErrRejected, but got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Error(err)
+			}
+			if eSQL := "SELECT * FROM users LIMIT " + d.want; eSQL != query {
+				t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+			}
+		})
+	}
+}
+
+func TestOneOfFunc(t *testing.T) {
+	s := `SELECT * FROM users ORDER BY id /*% oneOf "dir" "ASC" "DESC" %*/`
+	query, _, err := sqlt.New(sqlt.Postgres).WithOptions(sqlt.Annotation()).Exec(s, singleMap("dir", "desc"))
+	if err != nil {
+		t.Error(err)
+	}
+	if eSQL := "SELECT * FROM users ORDER BY id DESC/*# dir */"; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+
+	query, _, err = sqlt.New(sqlt.Postgres).WithOptions(sqlt.Annotation()).Exec(s, singleMap("dir", "DESC; DROP TABLE users"))
+	if _, ok := err.(*sqlt.ErrRejected); !ok {
+		t.Errorf("should raise ErrRejected, but got %v", err)
+	}
+	if eSQL := `SELECT * FROM users ORDER BY id /*# error: "dir" is rejected by oneOf policy: not one of ASC, DESC */`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+}
+
+func TestLitFunc(t *testing.T) {
+	tm := time.Date(2018, 8, 4, 12, 34, 56, 0, time.UTC)
+	jst := time.Date(2018, 8, 4, 21, 34, 56, 0, time.FixedZone("JST", 9*60*60))
+	name := "Alex"
+	data := []struct {
+		dialect sqlt.Dialect
+		val     interface{}
+		want    string
+		tag     string
+	}{
+		{sqlt.Postgres, "O'Reilly", `'O''Reilly'`, "postgres quote"},
+		{sqlt.Postgres, `C:\tmp`, `E'C:\\tmp'`, "postgres backslash"},
+		{sqlt.MySQL, `it's\`, `'it''s\\'`, "mysql backslash"},
+		{sqlt.SQLServer, "日本", `N'日本'`, "sqlserver unicode"},
+		{sqlt.Oracle, tm, `TIMESTAMP '2018-08-04 12:34:56 +00:00'`, "oracle timestamp"},
+		{sqlt.MySQL, jst, `TIMESTAMP '2018-08-04 12:34:56'`, "mysql timestamp in UTC"},
+		{sqlt.SQLServer, jst, `CAST('2018-08-04 21:34:56 +09:00' AS DATETIMEOFFSET)`, "sqlserver datetimeoffset"},
+		{sqlt.Postgres, []byte{0xde, 0xad}, `decode('dead', 'hex')`, "postgres bytea"},
+		{sqlt.Postgres, nil, `NULL`, "null"},
+		{sqlt.Postgres, (*string)(nil), `NULL`, "nil pointer"},
+		{sqlt.Postgres, &name, `'Alex'`, "pointer"},
+		{sqlt.SQLServer, true, `1`, "sqlserver bool"},
+		{sqlt.Postgres, sql.NullString{String: "Alex", Valid: true}, `'Alex'`, "valuer"},
+		{sqlt.Postgres, sql.NullInt64{}, `NULL`, "invalid valuer"},
+		{sqlt.Postgres, float32(0.1), `0.1`, "float32"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			query, _, err := sqlt.New(d.dialect).Exec(`CREATE VIEW v AS SELECT /*% lit "val" %*/'' AS c`, singleMap("val", d.val))
+			if err != nil {
+				t.Error(err)
+			}
+			if eSQL := "CREATE VIEW v AS SELECT " + d.want + " AS c"; eSQL != query {
+				t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+			}
+		})
+	}
+}
+
+func TestLitFuncError(t *testing.T) {
+	data := []struct {
+		val interface{}
+		tag string
+	}{
+		{"a\x00b", "NUL character"},
+		{[]int{1}, "unsupported type"},
+		{math.NaN(), "NaN"},
+		{math.Inf(1), "positive infinity"},
+		{math.Inf(-1), "negative infinity"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			if _, _, err := sqlt.New(sqlt.Postgres).Exec(`SELECT /*% lit "val" %*/''`, singleMap("val", d.val)); err == nil {
+				t.Error("should raise error")
+			}
+		})
+	}
+}
+
+func tenantFilter(b sqlt.Binder, args ...interface{}) (string, error) {
+	if len(args) != 1 {
+		return "", errors.New("tenantFilter requires column")
+	}
+	v, err := b.Lookup("tenant.ID")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v = %s", args[0], b.Bind("tenant_id", v)), nil
+}
+
+func TestAddBinderFunc(t *testing.T) {
+	s := `SELECT * FROM users u JOIN groups g ON u.group_id = g.id WHERE /*% tenantFilter "u.tenant_id" %*/ AND /*% tenantFilter "g.tenant_id" %*/ AND u.name = /*% p "name" %*/'Alex'`
+	m := map[string]interface{}{
+		"tenant": struct{ ID int }{ID: 10},
+		"name":   "Alex",
+	}
+	st := sqlt.New(sqlt.Postgres).AddBinderFunc("tenantFilter", tenantFilter)
+	query, args, err := st.Exec(s, m)
+	if err != nil {
+		t.Error(err)
+	}
+	if eSQL := `SELECT * FROM users u JOIN groups g ON u.group_id = g.id WHERE u.tenant_id = $1 AND g.tenant_id = $1 AND u.name = $2`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 2 {
+		t.Fatalf("exec failed: expected 2 args, but got %d", len(args))
+	}
+	if args[0] != 10 || args[1] != "Alex" {
+		t.Errorf("exec failed: unexpected args %v", args)
+	}
+
+	query, namedArgs, err := st.ExecNamed(s, m)
+	if err != nil {
+		t.Error(err)
+	}
+	if eSQL := `SELECT * FROM users u JOIN groups g ON u.group_id = g.id WHERE u.tenant_id = :tenant_id__bind1 AND g.tenant_id = :tenant_id__bind1 AND u.name = :name`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(namedArgs) != 2 {
+		t.Errorf("exec failed: expected 2 args, but got %d", len(namedArgs))
+	}
+}
+
+func TestAddBinderFuncCollision(t *testing.T) {
+	s := `SELECT * FROM users WHERE id = /*% p "id" %*/1 OR id = /*% override %*/1 OR id = /*% override %*/1 OR id = /*% again %*/1`
+	st := sqlt.New(sqlt.Postgres).AddBinderFunc("override", func(b sqlt.Binder, args ...interface{}) (string, error) {
+		return b.Bind("id", 999), nil
+	}).AddBinderFunc("again", func(b sqlt.Binder, args ...interface{}) (string, error) {
+		return b.Placeholder("id")
+	})
+	query, args, err := st.Exec(s, singleMap("id", 1))
+	if err != nil {
+		t.Error(err)
+	}
+	if eSQL := `SELECT * FROM users WHERE id = $1 OR id = $2 OR id = $2 OR id = $2`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 2 || args[0] != 1 || args[1] != 999 {
+		t.Errorf("exec failed: unexpected args %v", args)
+	}
+
+	if _, _, err = st.Exec(`SELECT * FROM users WHERE id = /*% again %*/1`, singleMap("id", 1)); err == nil {
+		t.Error("should raise error when placeholder is not bound")
+	}
+}
+
+func TestAddBinderFuncPlaceholderOnPositional(t *testing.T) {
+	s := `SELECT * FROM users WHERE id = /*% override %*/1 OR id = /*% again %*/1 OR id = /*% p "id" %*/1`
+	st := sqlt.New(sqlt.MySQL).AddBinderFunc("override", func(b sqlt.Binder, args ...interface{}) (string, error) {
+		return b.Bind("id", 999), nil
+	}).AddBinderFunc("again", func(b sqlt.Binder, args ...interface{}) (string, error) {
+		return b.Placeholder("id")
+	}).WithOptions(sqlt.Verification())
+	query, args, err := st.Exec(s, singleMap("id", 1))
+	if err != nil {
+		t.Error(err)
+	}
+	if eSQL := `SELECT * FROM users WHERE id = ? OR id = ? OR id = ?`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 3 || args[0] != 999 || args[1] != 999 || args[2] != 1 {
+		t.Errorf("exec failed: unexpected args %v", args)
+	}
+}
+
+func TestAddBinderFuncError(t *testing.T) {
+	s := `SELECT * FROM users WHERE /*% tenantFilter "tenant_id" %*/`
+	st := sqlt.New(sqlt.Postgres).AddBinderFunc("tenantFilter", tenantFilter)
+	if _, _, err := st.Exec(s, map[string]interface{}{}); err == nil {
+		t.Error("should raise error when parameter is missing")
+	}
+
+	query, _, err := st.WithOptions(sqlt.Annotation()).Exec(`SELECT * FROM users WHERE /*% tenantFilter %*/`, map[string]interface{}{})
+	if err == nil {
+		t.Error("should raise error")
+	}
+	if eSQL := `SELECT * FROM users WHERE /*# error: tenantFilter requires column */`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+}
+
+func TestBindValueCollision(t *testing.T) {
+	s := `SELECT /*% pv 100 %*/ , /*% in "pv" %*/(1) , /*% p "pv__value1" %*/1 , /*% pv 200 %*/`
+	m := map[string]interface{}{
+		"pv":         []int{7, 8},
+		"pv__value1": 9,
+	}
+	query, args, err := sqlt.New(sqlt.Postgres).Exec(s, m)
+	if err != nil {
+		t.Error(err)
+	}
+	if eSQL := `SELECT $1 , ($2, $3) , $4 , $5`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	expected := []interface{}{100, 7, 8, 9, 200}
+	if fmt.Sprint(args) != fmt.Sprint(expected) {
+		t.Errorf("exec failed: expected %v, but got %v", expected, args)
+	}
+
+	query, namedArgs, err := sqlt.New(sqlt.Postgres).ExecNamed(s, m)
+	if err != nil {
+		t.Error(err)
+	}
+	if eSQL := `SELECT :pv__value2 , (:pv__1, :pv__2) , :pv__value1 , :pv__value3`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(namedArgs) != 5 {
+		t.Errorf("exec failed: expected 5 args, but got %v", namedArgs)
+	}
+}
+
+func TestBindValue(t *testing.T) {
+	type item struct {
+		Name  string
+		Price int
+	}
+	s := `INSERT INTO items (name, price) VALUES
+/*%- range $i, $v := get "items" %*/
+/*%- if $i %*/ ,/*%- end %*/ (/*% pv $v.Name %*/'apple' , /*% bind $v.Price %*/100 )
+/*%- end %*/`
+	m := singleMap("items", []item{{"apple", 100}, {"orange", 100}, {"grape", 300}})
+	data := []struct {
+		dialect sqlt.Dialect
+		named   bool
+		sql     string
+		args    int
+		tag     string
+	}{
+		{sqlt.Postgres, false, `INSERT INTO items (name, price) VALUES ($1 , $2 ) , ($3 , $2 ) , ($4 , $5 )`, 5, "ordinal"},
+		{sqlt.Postgres, true, `INSERT INTO items (name, price) VALUES (:pv__value1 , :pv__value2 ) , (:pv__value3 , :pv__value2 ) , (:pv__value4 , :pv__value5 )`, 5, "named"},
+		{sqlt.MySQL, false, `INSERT INTO items (name, price) VALUES (? , ? ) , (? , ? ) , (? , ? )`, 6, "positional"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			var query string
+			var args int
+			var err error
+			if d.named {
+				var namedArgs []sql.NamedArg
+				query, namedArgs, err = sqlt.New(d.dialect).ExecNamed(s, m)
+				args = len(namedArgs)
+			} else {
+				var ordArgs []interface{}
+				query, ordArgs, err = sqlt.New(d.dialect).Exec(s, m)
+				args = len(ordArgs)
+			}
+			if err != nil {
+				t.Error(err)
+			}
+			if d.sql != query {
+				t.Errorf("exec failed: expected %s, but got %s", d.sql, query)
+			}
+			if d.args != args {
+				t.Errorf("exec failed: expected %d args, but got %d", d.args, args)
+			}
+		})
+	}
+}
+
+func TestLetAndAlias(t *testing.T) {
+	type address struct {
+		City string
+		Zip  string
+	}
+	type customer struct {
+		Address address
+	}
+	type order struct {
+		Customer customer
+	}
+	s := `/*%- alias "u" "order.Customer.Address" %*/
+/*%- let "city" "Osaka" %*/
+SELECT * FROM orders
+WHERE city = /*% p "u.City" %*/'Tokyo'
+AND zip = /*% p "u.Zip" %*/'100-0001'
+AND branch_city = /*% p "city" %*/'Tokyo'
+/*%- let "city" "Kyoto" %*/
+AND other_city = /*% p "city" %*/'Tokyo'
+AND root_city = /*% p "u__City" %*/'Tokyo'`
+	m := map[string]interface{}{
+		"order":   order{Customer: customer{Address: address{City: "Tokyo", Zip: "100-0001"}}},
+		"city":    "Nagoya",
+		"u__City": "Sapporo",
+	}
+	query, args, err := sqlt.New(sqlt.Postgres).ExecNamed(s, m)
+	if err != nil {
+		t.Error(err)
+	}
+	eSQL := `
+SELECT * FROM orders
+WHERE city = :order__Customer__Address__City
+AND zip = :order__Customer__Address__Zip
+AND branch_city = :city__let2
+AND other_city = :city__let3
+AND root_city = :u__City`
+	if eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	expected := []sql.NamedArg{
+		sql.Named("order__Customer__Address__City", "Tokyo"),
+		sql.Named("order__Customer__Address__Zip", "100-0001"),
+		sql.Named("city__let2", "Osaka"),
+		sql.Named("city__let3", "Kyoto"),
+		sql.Named("u__City", "Sapporo"),
+	}
+	if len(args) != len(expected) {
+		t.Fatalf("exec failed: expected %d args, but got %d", len(expected), len(args))
+	}
+	for i, a := range expected {
+		if a != args[i] {
+			t.Errorf("exec failed: expected %v, but got %v", a, args[i])
+		}
+	}
+}
+
+func TestLetCollision(t *testing.T) {
+	s := `/*%- let "x" 1 %*/
+SELECT /*% p "x" %*/1 , /*% p "x__let1" %*/1`
+	m := map[string]interface{}{"x__let1": 99}
+	query, args, err := sqlt.New(sqlt.Postgres).Exec(s, m)
+	if err != nil {
+		t.Error(err)
+	}
+	if eSQL := "\nSELECT $1 , $2"; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 2 || args[0] != 1 || args[1] != 99 {
+		t.Errorf("exec failed: unexpected args %v", args)
+	}
+
+	query, namedArgs, err := sqlt.New(sqlt.Postgres).ExecNamed(s, m)
+	if err != nil {
+		t.Error(err)
+	}
+	if eSQL := "\nSELECT :x__let2 , :x__let1"; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	expected := []sql.NamedArg{sql.Named("x__let2", 1), sql.Named("x__let1", 99)}
+	if len(namedArgs) != len(expected) {
+		t.Fatalf("exec failed: expected %v, but got %v", expected, namedArgs)
+	}
+	for i, a := range expected {
+		if a != namedArgs[i] {
+			t.Errorf("exec failed: expected %v, but got %v", a, namedArgs[i])
+		}
+	}
+}
+
+func TestLetAndAliasResult(t *testing.T) {
+	type address struct {
+		City string
+	}
+	type user struct {
+		Address address
+	}
+	s := `/*%- alias "u" "user.Address" %*/
+/*%- let "z" (get "zip") %*/
+/*%- let "v" (get "user") %*/
+SELECT * FROM users
+WHERE city = /*% p "u.City" %*/'Tokyo'
+AND zip = /*% p "z" %*/'100-0001'
+AND pref = /*% p "v.Address.City" %*/'Tokyo'`
+	r, err := sqlt.New(sqlt.Postgres).Render(s, map[string]interface{}{
+		"user": user{Address: address{City: "Tokyo"}},
+		"zip":  "100-0001",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	eBindings := []sqlt.Binding{
+		{Placeholder: "$1", Name: "user__Address__City", Path: "user.Address.City"},
+		{Placeholder: "$2", Name: "z__let2", Path: ""},
+		{Placeholder: "$3", Name: "v__let3__Address__City", Path: ""},
+	}
+	if len(r.Bindings) != len(eBindings) {
+		t.Fatalf("render failed: expected bindings %v, but got %v", eBindings, r.Bindings)
+	}
+	for i, b := range eBindings {
+		if r.Bindings[i] != b {
+			t.Errorf("render failed: expected binding %v, but got %v", b, r.Bindings[i])
+		}
+	}
+	eParams := []string{"user.Address", "zip", "user", "user.Address.City"}
+	if fmt.Sprint(r.Params) != fmt.Sprint(eParams) {
+		t.Errorf("render failed: expected params %v, but got %v", eParams, r.Params)
+	}
+}
+
+func TestAliasError(t *testing.T) {
+	data := []struct {
+		text string
+		tag  string
+	}{
+		{`SELECT /*% alias "u" "order.Customer" %*/1`, "unknown path"},
+		{`SELECT /*% alias "u.v" "order" %*/1`, "dotted alias"},
+		{`SELECT /*% let "u.v" 1 %*/1`, "dotted local"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			if _, _, err := sqlt.New(sqlt.Postgres).Exec(d.text, singleMap("order", 1)); err == nil {
+				t.Error("should raise error")
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type filter struct {
+		Status []string `json:"status"`
+		Min    int      `json:"min"`
+	}
+	s := `SELECT * FROM searches WHERE filter @> /*% json "filter" %*/'{}'`
+	data := []struct {
+		dialect sqlt.Dialect
+		sql     string
+		tag     string
+	}{
+		{sqlt.Postgres, `SELECT * FROM searches WHERE filter @> $1::jsonb`, "postgres"},
+		{sqlt.MySQL, `SELECT * FROM searches WHERE filter @> CAST(? AS JSON)`, "mysql"},
+		{sqlt.SQLServer, `SELECT * FROM searches WHERE filter @> @p1`, "sqlserver"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			query, args, err := sqlt.New(d.dialect).Exec(s, singleMap("filter", filter{Status: []string{"open"}, Min: 3}))
+			if err != nil {
+				t.Error(err)
+			}
+			if d.sql != query {
+				t.Errorf("exec failed: expected %s, but got %s", d.sql, query)
+			}
+			if eArg := `{"status":["open"],"min":3}`; len(args) != 1 || args[0] != eArg {
+				t.Errorf("exec failed: expected [%s], but got %v", eArg, args)
+			}
+		})
+	}
+}
+
+func TestJSONNamed(t *testing.T) {
+	s := `SELECT * FROM searches WHERE id = /*% p "filter.ID" %*/1 AND filter = /*% json "filter" %*/'{}'`
+	filter := struct{ ID int }{ID: 1}
+	query, args, err := sqlt.New(sqlt.Postgres).ExecNamed(s, singleMap("filter", filter))
+	if err != nil {
+		t.Error(err)
+	}
+	if eSQL := `SELECT * FROM searches WHERE id = :filter__ID AND filter = :filter__json::jsonb`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 2 || args[1].Value != `{"ID":1}` {
+		t.Errorf("exec failed: unexpected args %v", args)
+	}
+}
+
+func TestJSONRawMessage(t *testing.T) {
+	s := `SELECT * FROM searches WHERE filter = /*% json "filter" %*/'{}'`
+	data := []struct {
+		val   json.RawMessage
+		opts  []sqlt.Option
+		valid bool
+		tag   string
+	}{
+		{json.RawMessage(`{"a":1}`), nil, true, "valid"},
+		{json.RawMessage(`{"a":`), nil, true, "invalid without validation"},
+		{json.RawMessage(`{"a":1}`), []sqlt.Option{sqlt.StrictJSON()}, true, "valid with validation"},
+		{json.RawMessage(`{"a":`), []sqlt.Option{sqlt.StrictJSON()}, false, "invalid with validation"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			_, args, err := sqlt.New(sqlt.Postgres).Exec(s, singleMap("filter", d.val), d.opts...)
+			if !d.valid {
+				if err == nil {
+					t.Error("should raise error")
+				}
+				return
+			}
+			if err != nil {
+				t.Error(err)
+			}
+			if len(args) != 1 || args[0] != string(d.val) {
+				t.Errorf("exec failed: expected [%s], but got %v", string(d.val), args)
+			}
+		})
+	}
+}
+
+func TestCast(t *testing.T) {
+	tm := time.Date(2018, 8, 4, 0, 0, 0, 0, time.UTC)
+	n := 10
+	data := []struct {
+		val    interface{}
+		typ    string
+		layout string
+		want   interface{}
+		tag    string
+	}{
+		{"123", "int", "", int64(123), "string to int"},
+		{uint8(5), "int", "", int64(5), "uint to int"},
+		{&n, "int", "", int64(10), "pointer to int"},
+		{"1.5", "float", "", 1.5, "string to float"},
+		{"true", "bool", "", true, "string to bool"},
+		{"2018-08-04", "date", "", tm, "string to date"},
+		{"2018-08-04T00:00:00Z", "time", "", tm, "string to time"},
+		{"2018/08/04", "time", "2006/01/02", tm, "string to time with layout"},
+		{12, "string", "", "12", "int to string"},
+		{nil, "int", "", nil, "nil"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			s := fmt.Sprintf(`SELECT * FROM users WHERE c = /*%% cast "val" %q %q %%*/1`, d.typ, d.layout)
+			query, args, err := sqlt.New(sqlt.Postgres).Exec(s, singleMap("val", d.val))
+			if err != nil {
+				t.Error(err)
+			}
+			if eSQL := `SELECT * FROM users WHERE c = $1`; eSQL != query {
+				t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+			}
+			if len(args) != 1 || args[0] != d.want {
+				t.Errorf("exec failed: expected [%v], but got %v", d.want, args)
+			}
+		})
+	}
+}
+
+func TestCastWithSQLCast(t *testing.T) {
+	s := `SELECT * FROM users WHERE id = /*% cast "id" "int" %*/1 AND active = /*% cast "active" "bool" %*/true`
+	m := map[string]interface{}{"id": "1", "active": "1"}
+	data := []struct {
+		dialect sqlt.Dialect
+		sql     string
+		tag     string
+	}{
+		{sqlt.Postgres, `SELECT * FROM users WHERE id = CAST($1 AS BIGINT) AND active = CAST($2 AS BOOLEAN)`, "postgres"},
+		{sqlt.MySQL, `SELECT * FROM users WHERE id = CAST(? AS SIGNED) AND active = ?`, "mysql"},
+		{sqlt.Oracle, `SELECT * FROM users WHERE id = CAST(:1 AS NUMBER(19)) AND active = :2`, "oracle"},
+		{sqlt.SQLServer, `SELECT * FROM users WHERE id = CAST(@p1 AS BIGINT) AND active = CAST(@p2 AS BIT)`, "sqlserver"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			query, _, err := sqlt.New(d.dialect).Exec(s, m, sqlt.SQLCast())
+			if err != nil {
+				t.Error(err)
+			}
+			if d.sql != query {
+				t.Errorf("exec failed: expected %s, but got %s", d.sql, query)
+			}
+		})
+	}
+}
+
+func TestCastError(t *testing.T) {
+	data := []struct {
+		val interface{}
+		typ string
+		msg string
+		tag string
+	}{
+		{"abc", "int", `"val" cannot be converted to int(invalid syntax)`, "invalid int"},
+		{"yes!", "bool", `"val" cannot be converted to bool(invalid syntax)`, "invalid bool"},
+		{"2018/08/04", "date", `"val" cannot be converted to date(not match layout 2006-01-02)`, "invalid date"},
+		{1, "uuid", `"val" cannot be converted to uuid(unknown type)`, "unknown type"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			s := fmt.Sprintf(`SELECT * FROM users WHERE c = /*%% cast "val" %q %%*/1`, d.typ)
+			_, _, err := sqlt.New(sqlt.Postgres).Exec(s, singleMap("val", d.val))
+			e, ok := err.(*sqlt.ErrConversion)
+			if !ok {
+				t.Fatalf("should raise ErrConversion, but got %v", err)
+			}
+			if e.Param != "val" || e.Type != d.typ || e.Value != d.val {
+				t.Errorf("exec failed: unexpected error fields %#v", e)
+			}
+			if d.msg != e.Error() {
+				t.Errorf("exec failed: expected %s, but got %s", d.msg, e.Error())
+			}
+		})
+	}
+}
+
+type failValuer struct{}
+
+func (v failValuer) Value() (driver.Value, error) {
+	return nil, errors.New("failed")
+}
+
+func TestEqual(t *testing.T) {
+	n := 1
+	data := []struct {
+		val  interface{}
+		eq   string
+		ne   string
+		args int
+		tag  string
+	}{
+		{1, `c = $1`, `c <> $1`, 1, "value"},
+		{&n, `c = $1`, `c <> $1`, 1, "pointer"},
+		{nil, `c IS NULL`, `c IS NOT NULL`, 0, "nil"},
+		{(*int)(nil), `c IS NULL`, `c IS NOT NULL`, 0, "nil pointer"},
+		{sql.NullInt64{Int64: 1, Valid: true}, `c = $1`, `c <> $1`, 1, "valid valuer"},
+		{sql.NullInt64{}, `c IS NULL`, `c IS NOT NULL`, 0, "invalid valuer"},
+		{&sql.NullString{}, `c IS NULL`, `c IS NOT NULL`, 0, "pointer of invalid valuer"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			query, args, err := sqlt.New(sqlt.Postgres).Exec(`SELECT * FROM t WHERE /*% equal "c" "val" %*/ AND /*% notEqual "c" "val" %*/`, singleMap("val", d.val))
+			if err != nil {
+				t.Error(err)
+			}
+			if eSQL := "SELECT * FROM t WHERE " + d.eq + " AND " + d.ne; eSQL != query {
+				t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+			}
+			if d.args != len(args) {
+				t.Errorf("exec failed: expected %d args, but got %d", d.args, len(args))
+			}
+		})
+	}
+
+	if _, _, err := sqlt.New(sqlt.Postgres).Exec(`SELECT * FROM t WHERE /*% equal "c" "val" %*/`, singleMap("val", failValuer{})); err == nil {
+		t.Error("should raise error when Value fails")
+	}
+}
+
+func TestEqualWithNestedField(t *testing.T) {
+	type filter struct {
+		Email *string
+		Code  sql.NullString
+		Any   interface{}
+	}
+	email := "alex@example.com"
+	s := `SELECT * FROM users u WHERE /*% equal "u.email" "filter.Email" %*/ AND /*% notEqual "u.code" "filter.Code" %*/ AND /*% equal "u.any" "filter.Any" %*/`
+	data := []struct {
+		filter interface{}
+		sql    string
+		args   int
+		tag    string
+	}{
+		{filter{Email: &email, Code: sql.NullString{String: "A", Valid: true}, Any: 1}, `SELECT * FROM users u WHERE u.email = $1 AND u.code <> $2 AND u.any = $3`, 3, "values"},
+		{filter{}, `SELECT * FROM users u WHERE u.email IS NULL AND u.code IS NOT NULL AND u.any IS NULL`, 0, "nil fields"},
+		{&filter{}, `SELECT * FROM users u WHERE u.email IS NULL AND u.code IS NOT NULL AND u.any IS NULL`, 0, "pointer of struct"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			query, args, err := sqlt.New(sqlt.Postgres).Exec(s, singleMap("filter", d.filter))
+			if err != nil {
+				t.Error(err)
+			}
+			if d.sql != query {
+				t.Errorf("exec failed: expected %s, but got %s", d.sql, query)
+			}
+			if d.args != len(args) {
+				t.Errorf("exec failed: expected %d args, but got %d", d.args, len(args))
+			}
+		})
+	}
+
+	type outer struct {
+		Inner *filter
+	}
+	if _, _, err := sqlt.New(sqlt.Postgres).Exec(`SELECT * FROM users u WHERE /*% equal "u.email" "o.Inner.Email" %*/`, singleMap("o", outer{})); err == nil {
+		t.Error("should raise error when middle of path is nil")
+	}
+}
+
+func TestEqualWithNullSafeEquality(t *testing.T) {
+	s := `SELECT * FROM t WHERE /*% equal "c" "val" %*/ AND /*% notEqual "c" "val" %*/`
+	data := []struct {
+		dialect sqlt.Dialect
+		sql     string
+		tag     string
+	}{
+		{sqlt.Postgres, `SELECT * FROM t WHERE c IS NOT DISTINCT FROM $1 AND c IS DISTINCT FROM $1`, "postgres"},
+		{sqlt.MySQL, `SELECT * FROM t WHERE c <=> ? AND NOT (c <=> ?)`, "mysql"},
+		{sqlt.SQLite, `SELECT * FROM t WHERE c IS ?1 AND c IS NOT ?1`, "sqlite"},
+		{sqlt.Oracle, `SELECT * FROM t WHERE c = :1 AND c <> :1`, "not supported"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			query, _, err := sqlt.New(d.dialect).Exec(s, singleMap("val", 1), sqlt.NullSafeEquality())
+			if err != nil {
+				t.Error(err)
+			}
+			if d.sql != query {
+				t.Errorf("exec failed: expected %s, but got %s", d.sql, query)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	from := time.Date(2018, 8, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	data := []struct {
+		from   interface{}
+		to     interface{}
+		bounds string
+		sql    string
+		args   int
+		tag    string
+	}{
+		{from, to, "[]", `created_at BETWEEN $1 AND $2`, 2, "closed"},
+		{from, to, "[)", `created_at >= $1 AND created_at < $2`, 2, "half-open"},
+		{from, to, "()", `created_at > $1 AND created_at < $2`, 2, "open"},
+		{from, nil, "[]", `created_at >= $1`, 1, "only from"},
+		{from, nil, "(]", `created_at > $1`, 1, "only exclusive from"},
+		{nil, to, "[]", `created_at <= $1`, 1, "only to"},
+		{nil, to, "[)", `created_at < $1`, 1, "only exclusive to"},
+		{"", (*time.Time)(nil), "[]", ``, 0, "no bounds"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			s := fmt.Sprintf(`SELECT * FROM users WHERE /*%% between "created_at" "from" "to" %q %%*/`, d.bounds)
+			m := map[string]interface{}{"from": d.from, "to": d.to}
+			query, args, err := sqlt.New(sqlt.Postgres).Exec(s, m)
+			if err != nil {
+				t.Error(err)
+			}
+			if eSQL := "SELECT * FROM users WHERE " + d.sql; eSQL != query {
+				t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+			}
+			if d.args != len(args) {
+				t.Errorf("exec failed: expected %d args, but got %d", d.args, len(args))
+			}
+		})
+	}
+}
+
+func TestBetweenWithNestedField(t *testing.T) {
+	type period struct {
+		From *time.Time
+		To   *time.Time
+	}
+	tm := time.Date(2018, 8, 1, 0, 0, 0, 0, time.UTC)
+	s := `SELECT * FROM users WHERE /*% between "created_at" "f.From" "f.To" "[)" %*/`
+	data := []struct {
+		filter period
+		sql    string
+		tag    string
+	}{
+		{period{From: &tm, To: &tm}, `SELECT * FROM users WHERE created_at >= $1 AND created_at < $2`, "both"},
+		{period{From: &tm}, `SELECT * FROM users WHERE created_at >= $1`, "only from"},
+		{period{To: &tm}, `SELECT * FROM users WHERE created_at < $1`, "only to"},
+		{period{}, `SELECT * FROM users WHERE `, "none"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			query, _, err := sqlt.New(sqlt.Postgres).Exec(s, singleMap("f", d.filter))
+			if err != nil {
+				t.Error(err)
+			}
+			if d.sql != query {
+				t.Errorf("exec failed: expected %s, but got %s", d.sql, query)
+			}
+		})
+	}
+}
+
+func TestBetweenError(t *testing.T) {
+	m := map[string]interface{}{"from": 1, "to": 2}
+	if _, _, err := sqlt.New(sqlt.Postgres).Exec(`SELECT * FROM t WHERE /*% between "c" "from" "to" "[[" %*/`, m); err == nil {
+		t.Error("should raise error for invalid bounds")
+	}
+	if _, _, err := sqlt.New(sqlt.Postgres).Exec(`SELECT * FROM t WHERE /*% between "c" "from" "until" %*/`, m); err == nil {
+		t.Error("should raise error for unknown param")
+	}
+}
+
+type userFilter struct {
+	Name      string     `sqlt:"u.name,op=infix"`
+	Statuses  []string   `sqlt:"u.status,op=in"`
+	CreatedAt *time.Time `sqlt:"u.created_at,op=gte"`
+	GroupID   int        `sqlt:"u.group_id"`
+	Code      string     `sqlt:"u.code,op=prefix"`
+	Memo      string
+	Ignored   string `sqlt:"-"`
+}
+
+func TestCriteria(t *testing.T) {
+	tm := time.Date(2018, 8, 4, 0, 0, 0, 0, time.UTC)
+	s := `SELECT * FROM users u WHERE /*% criteria "filter" %*/ ORDER BY u.id`
+	data := []struct {
+		filter interface{}
+		sql    string
+		args   int
+		tag    string
+	}{
+		{
+			userFilter{Name: "50%", Statuses: []string{"active", "locked"}, CreatedAt: &tm, GroupID: 3, Memo: "x", Ignored: "y"},
+			`SELECT * FROM users u WHERE u.name LIKE '%' || $1 || '%' ESCAPE '\' AND u.status IN ($2, $3) AND u.created_at >= $4 AND u.group_id = $5 ORDER BY u.id`,
+			5,
+			"all fields",
+		},
+		{
+			&userFilter{Code: "A_", Statuses: []string{}},
+			`SELECT * FROM users u WHERE u.code LIKE $1 || '%' ESCAPE '\' ORDER BY u.id`,
+			1,
+			"pointer",
+		},
+		{
+			userFilter{},
+			`SELECT * FROM users u WHERE 1 = 1 ORDER BY u.id`,
+			0,
+			"no fields",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			query, args, err := sqlt.New(sqlt.Postgres).Exec(s, singleMap("filter", d.filter))
+			if err != nil {
+				t.Error(err)
+			}
+			if d.sql != query {
+				t.Errorf("exec failed: expected %s, but got %s", d.sql, query)
+			}
+			if d.args != len(args) {
+				t.Errorf("exec failed: expected %d args, but got %d", d.args, len(args))
+			}
+		})
+	}
+}
+
+func TestCriteriaNamed(t *testing.T) {
+	s := `SELECT * FROM users u WHERE /*% criteria "filter" %*/`
+	query, args, err := sqlt.New(sqlt.Postgres).ExecNamed(s, singleMap("filter", userFilter{Name: "a_b", Statuses: []string{"active"}}))
+	if err != nil {
+		t.Error(err)
+	}
+	if eSQL := `SELECT * FROM users u WHERE u.name LIKE '%' || :filter__Name__esc || '%' ESCAPE '\' AND u.status IN (:filter__Statuses__1)`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 2 || args[0].Value != `a\_b` || args[1].Value != "active" {
+		t.Errorf("exec failed: unexpected args %v", args)
+	}
+}
+
+func TestCriteriaError(t *testing.T) {
+	type invalidFilter struct {
+		Name string `sqlt:"name,op=like"`
+	}
+	data := []struct {
+		filter interface{}
+		tag    string
+	}{
+		{invalidFilter{Name: "a"}, "unknown operator"},
+		{1, "not struct"},
+		{(*userFilter)(nil), "nil"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			if _, _, err := sqlt.New(sqlt.Postgres).Exec(`SELECT * FROM users WHERE /*% criteria "filter" %*/`, singleMap("filter", d.filter)); err == nil {
+				t.Error("should raise error")
+			}
+		})
+	}
+}
+
+type insertUser struct {
+	ID        int       `sqlt:"id,auto"`
+	Name      string    `sqlt:"name"`
+	Email     string    `sqlt:"email,omitempty"`
+	Age       int       `sqlt:"age"`
+	CreatedAt time.Time `sqlt:"created_at,time"`
+	UpdatedAt time.Time `sqlt:"updated_at,time"`
+	Memo      string
+}
+
+func TestColumnsAndInsertValues(t *testing.T) {
+	tm := time.Date(2018, 8, 4, 12, 34, 56, 0, time.UTC)
+	s := `INSERT INTO users /*% columns "user" %*/ VALUES /*% insertValues "user" %*/`
+	data := []struct {
+		user insertUser
+		sql  string
+		args []interface{}
+		tag  string
+	}{
+		{
+			insertUser{ID: 1, Name: "Alex", Email: "alex@example.com", Age: 20, Memo: "x"},
+			`INSERT INTO users (name, email, age, created_at, updated_at) VALUES ($1, $2, $3, $4, $4)`,
+			[]interface{}{"Alex", "alex@example.com", 20, tm},
+			"all columns",
+		},
+		{
+			insertUser{Name: "Alex"},
+			`INSERT INTO users (name, age, created_at, updated_at) VALUES ($1, $2, $3, $3)`,
+			[]interface{}{"Alex", 0, tm},
+			"omit empty",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			query, args, err := sqlt.New(sqlt.Postgres).Exec(s, singleMap("user", &d.user), sqlt.TimeFunc(func() time.Time { return tm }))
+			if err != nil {
+				t.Error(err)
+			}
+			if d.sql != query {
+				t.Errorf("exec failed: expected %s, but got %s", d.sql, query)
+			}
+			if len(d.args) != len(args) {
+				t.Fatalf("exec failed: expected %v, but got %v", d.args, args)
+			}
+			for i, a := range d.args {
+				if a != args[i] {
+					t.Errorf("exec failed: expected %v, but got %v", a, args[i])
+				}
+			}
+		})
+	}
+}
+
+func TestInsert(t *testing.T) {
+	tm := time.Date(2018, 8, 4, 12, 34, 56, 0, time.UTC)
+	u := insertUser{Name: "Alex", Age: 20}
+	query, args, err := sqlt.New(sqlt.MySQL).Exec(`/*% insert "users" "user" %*/`, singleMap("user", u), sqlt.TimeFunc(func() time.Time { return tm }))
+	if err != nil {
+		t.Error(err)
+	}
+	if eSQL := `INSERT INTO users (name, age, created_at, updated_at) VALUES (?, ?, ?, ?)`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+	if len(args) != 4 {
+		t.Errorf("exec failed: expected 4 args, but got %d", len(args))
+	}
+
+	query, _, err = sqlt.New(sqlt.Postgres).ExecNamed(`/*% insert "users" "user" %*/`, singleMap("user", u), sqlt.TimeFunc(func() time.Time { return tm }))
+	if err != nil {
+		t.Error(err)
+	}
+	if eSQL := `INSERT INTO users (name, age, created_at, updated_at) VALUES (:user__Name, :user__Age, :time__, :time__)`; eSQL != query {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, query)
+	}
+}
+
+func TestInsertWithNilPointer(t *testing.T) {
+	type nullableUser struct {
+		Name     string  `sqlt:"name"`
+		Nickname *string `sqlt:"nickname"`
+	}
+	r, err := sqlt.New(sqlt.Postgres).Render(`/*% insert "users" "u" %*/`, singleMap("u", nullableUser{Name: "Alex"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eSQL := `INSERT INTO users (name, nickname) VALUES ($1, $2)`; eSQL != r.SQL {
+		t.Errorf("exec failed: expected %s, but got %s", eSQL, r.SQL)
+	}
+	if len(r.Args) != 2 {
+		t.Fatalf("exec failed: expected 2 args, but got %v", r.Args)
+	}
+	if v, ok := r.Args[1].(*string); !ok || v != nil {
+		t.Errorf("exec failed: 2nd value should be nil pointer, but got %v", r.Args[1])
+	}
+	if b := r.Bindings[1]; b.Name != "u__Nickname" || b.Path != "u.Nickname" {
+		t.Errorf("exec failed: unexpected binding %v", b)
+	}
+}
+
+func TestInsertError(t *testing.T) {
+	type onlyAuto struct {
+		ID int `sqlt:"id,auto"`
+	}
+	data := []struct {
+		val interface{}
+		tag string
+	}{
+		{onlyAuto{ID: 1}, "no column"},
+		{"user", "not struct"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			if _, _, err := sqlt.New(sqlt.Postgres).Exec(`/*% insert "users" "user" %*/`, singleMap("user", d.val)); err == nil {
+				t.Error("should raise error")
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package sqlt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrIntegrity is error that rendered SQL violates statement integrity.
+type ErrIntegrity struct {
+	// Violations are descriptions of each violation.
+	Violations []string
+}
+
+func (e *ErrIntegrity) Error() string {
+	return "integrity check failed: " + strings.Join(e.Violations, ", ")
+}
+
+// verify checks that rendered SQL is single statement, contains no comment except annotations and hints,
+// and has placeholders that match args.
+func (c *context) verify(s string) error {
+	violations := make([]string, 0)
+	ordinals := make(map[int]bool)
+	names := make(map[string]bool)
+	positionals := 0
+	terminated := false
+	for _, tok := range scan(c.dialect, c.namedPlaceholderPrefix(), c.named, s) {
+		if terminated && strings.TrimSpace(tok.text) != "" {
+			violations = append(violations, "multiple statements")
+			terminated = false
+		}
+		switch tok.kind {
+		case textToken:
+			if i := strings.Index(tok.text, ";"); i >= 0 {
+				if strings.TrimSpace(strings.Replace(tok.text[i:], ";", "", -1)) != "" {
+					violations = append(violations, "multiple statements")
+				} else {
+					terminated = true
+				}
+			}
+		case commentToken:
+			if !strings.HasPrefix(tok.text, "/*#") && !strings.HasPrefix(tok.text, "/*+") {
+				violations = append(violations, fmt.Sprintf("comment %q", tok.text))
+			}
+		case ordinalToken:
+			ordinals[tok.index] = true
+		case namedToken:
+			names[tok.name] = true
+		case positionalToken:
+			positionals++
+		}
+	}
+
+	switch {
+	case c.named:
+		if len(ordinals) > 0 || positionals > 0 {
+			violations = append(violations, "unnamed placeholder in named statement")
+		}
+		for _, arg := range c.args {
+			if !names[arg.name] {
+				violations = append(violations, fmt.Sprintf("arg %q has no placeholder", arg.name))
+			}
+			delete(names, arg.name)
+		}
+		for name := range names {
+			violations = append(violations, fmt.Sprintf("placeholder %q has no arg", name))
+		}
+	case c.dialect.IsOrdinalPlaceholderSupported():
+		if positionals > 0 {
+			violations = append(violations, "positional placeholder in ordinal statement")
+		}
+		for i := range c.args {
+			if !ordinals[i+1] {
+				violations = append(violations, fmt.Sprintf("arg %d has no placeholder", i+1))
+			}
+			delete(ordinals, i+1)
+		}
+		for i := range ordinals {
+			violations = append(violations, fmt.Sprintf("placeholder %d has no arg", i))
+		}
+	default:
+		if positionals != len(c.args) {
+			violations = append(violations, fmt.Sprintf("%d placeholders for %d args", positionals, len(c.args)))
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ErrIntegrity{Violations: violations}
+	}
+	return nil
+}
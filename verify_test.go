@@ -0,0 +1,72 @@
+package sqlt_test
+
+import (
+	"testing"
+
+	"github.com/pinzolo/sqlt"
+)
+
+func TestVerification(t *testing.T) {
+	specMySQL, err := sqlt.NewDialect(sqlt.DialectSpec{
+		Placeholder:            "?",
+		NamedPlaceholderPrefix: ":",
+		QuoteStart:             "`",
+		BackslashEscape:        true,
+		HashComment:            true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []struct {
+		dialect sqlt.Dialect
+		named   bool
+		tmpl    string
+		valid   bool
+		tag     string
+	}{
+		{sqlt.Postgres, false, `SELECT * FROM users WHERE id = /*% p "id" %*/1 AND name = '$2;--'`, true, "valid"},
+		{sqlt.Postgres, false, `SELECT * FROM users WHERE id = /*% p "id" %*/1 AND deleted = 0;`, true, "terminated"},
+		{sqlt.Postgres, false, `SELECT /*+ INDEX(users) */ * FROM users WHERE id = /*% p "id" %*/1`, true, "hint"},
+		{sqlt.Postgres, false, `SELECT * FROM users WHERE id = /*% p "id" %*/1 AND deleted = 0; DELETE FROM users`, false, "multiple statements"},
+		{sqlt.Postgres, false, `SELECT * FROM users WHERE id = /*% p "id" %*/1 AND deleted = 0; 'x'`, false, "multiple statements after literal"},
+		{sqlt.Postgres, false, `SELECT * FROM users WHERE id = /*% p "id" %*/1 -- comment`, false, "line comment"},
+		{sqlt.Postgres, false, `SELECT * FROM users /* comment */ WHERE id = /*% p "id" %*/1`, false, "block comment"},
+		{sqlt.Postgres, false, `SELECT * FROM users WHERE id = /*% p "id" %*/1 AND age = $2`, false, "extra ordinal placeholder"},
+		{sqlt.Postgres, false, `SELECT * FROM users WHERE id = /*% broken "id" %*/1`, false, "broken custom func"},
+		{sqlt.MySQL, false, `SELECT * FROM users WHERE id = /*% p "id" %*/1 AND name = 'it\'s?'`, true, "mysql escaped quote"},
+		{sqlt.MySQL, false, `SELECT * FROM users WHERE id = /*% p "id" %*/1 AND age = ?`, false, "extra positional placeholder"},
+		{sqlt.MySQL, false, `SELECT * FROM users WHERE id = /*% p "id" %*/1 # comment`, false, "mysql comment"},
+		{sqlt.SQLServer, true, `SELECT * FROM users WHERE id = /*% p "id" %*/1`, true, "named"},
+		{sqlt.SQLServer, true, `SELECT * FROM users WHERE id = /*% p "id" %*/1 AND name = @name`, false, "extra named placeholder"},
+		{sqlt.SQLServer, true, `SELECT * FROM users WHERE id = /*% p "p1" %*/1`, true, "named like ordinal"},
+		{sqlt.SQLServer, false, `SELECT [a--b] FROM users WHERE id = /*% p "id" %*/1`, true, "bracketed identifier"},
+		{sqlt.Postgres, false, `SELECT $$a;b$$ FROM users WHERE id = /*% p "id" %*/1`, true, "dollar quote"},
+		{sqlt.Postgres, false, `SELECT $tag$a;$$b$tag$ FROM users WHERE id = /*% p "id" %*/1`, true, "tagged dollar quote"},
+		{sqlt.MySQL, false, "SELECT `a--b` FROM users WHERE id = /*% p \"id\" %*/1", true, "backquoted identifier"},
+		{specMySQL, false, `SELECT * FROM users WHERE id = /*% p "id" %*/1 AND name = 'it\'s?'`, true, "spec escaped quote"},
+		{specMySQL, false, `SELECT * FROM users WHERE id = /*% p "id" %*/1 # comment`, false, "spec hash comment"},
+	}
+	for _, d := range data {
+		t.Run(d.tag, func(t *testing.T) {
+			st := sqlt.New(d.dialect).AddFunc("broken", func(name string) string {
+				return "1; DROP TABLE users"
+			}).WithOptions(sqlt.Verification())
+			var err error
+			m := map[string]interface{}{"id": 1, "p1": 2}
+			if d.named {
+				_, _, err = st.ExecNamed(d.tmpl, m)
+			} else {
+				_, _, err = st.Exec(d.tmpl, m)
+			}
+			if d.valid {
+				if err != nil {
+					t.Error(err)
+				}
+				return
+			}
+			if _, ok := err.(*sqlt.ErrIntegrity); !ok {
+				t.Errorf("should raise ErrIntegrity, but got %v", err)
+			}
+		})
+	}
+}